@@ -3,6 +3,7 @@ package generator
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"google.golang.org/genproto/googleapis/api/annotations"
@@ -17,6 +18,8 @@ const (
 	loopValueAccessor = "v"
 
 	mapKeyVarName = "key"
+
+	httpBodyMessageName = "google.api.HttpBody"
 )
 
 func getClientInterfaceName(svc *protogen.Service) string {
@@ -27,6 +30,12 @@ func getClientStructName(svc *protogen.Service) string {
 	return unexport(getClientInterfaceName(svc))
 }
 
+// getFullMethodName returns the quoted full gRPC name of the method, e.g.
+// "/package.Service/Method".
+func getFullMethodName(m *protogen.Method) string {
+	return strconv.Quote(fmt.Sprintf("/%s/%s", m.Parent.Desc.FullName(), m.Desc.Name()))
+}
+
 type HTTPRule struct {
 	Method  string
 	Pattern string
@@ -93,7 +102,34 @@ func hasGatewayCompatibleMethods(file *protogen.File) bool {
 
 func isGatewayCompatibleMethod(m *protogen.Method) bool {
 	_, ok := getHTTPRule(m)
-	return ok && !m.Desc.IsStreamingClient()
+	return ok && (!m.Desc.IsStreamingClient() || isBidiStreamingMethod(m))
+}
+
+// isBidiStreamingMethod reports whether the method streams in both directions.
+// Bidirectional streams are served over WebSocket.
+func isBidiStreamingMethod(m *protogen.Method) bool {
+	return m.Desc.IsStreamingClient() && m.Desc.IsStreamingServer()
+}
+
+// isHTTPBodyUploadMethod reports whether the whole request body of the method
+// is a google.api.HttpBody, which is sent as raw bytes.
+func isHTTPBodyUploadMethod(m *protogen.Method) bool {
+	rule, ok := getHTTPRule(m)
+	if !ok || rule.Body != "*" || isBidiStreamingMethod(m) {
+		return false
+	}
+	return isHTTPBodyMessage(m.Input)
+}
+
+// isIdempotentMethod reports whether the method is marked with the
+// idempotency_level option, so that it can be retried whatever its HTTP method.
+func isIdempotentMethod(m *protogen.Method) bool {
+	options, ok := m.Desc.Options().(*descriptorpb.MethodOptions)
+	return ok && options.GetIdempotencyLevel() != descriptorpb.MethodOptions_IDEMPOTENCY_UNKNOWN
+}
+
+func isHTTPBodyMessage(msg *protogen.Message) bool {
+	return msg != nil && msg.Desc.FullName() == httpBodyMessageName
 }
 
 func generateQueryParam(
@@ -151,13 +187,20 @@ func generateQueryParam(
 	}
 }
 
+func generateNewRequest(g *protogen.GeneratedFile, m *protogen.Method, rule HTTPRule) {
+	g.P(`gwReq := c.gwc.NewRequest("`, rule.Method, `", "`, rule.Pattern, `")`)
+	if isIdempotentMethod(m) {
+		g.P("ctx = ", pkgGatewayClient.Ident("SetIdempotent"), "(ctx)")
+	}
+}
+
 func generateParamValues(g *protogen.GeneratedFile, m *protogen.Method) {
 	rule, ok := getHTTPRule(m)
 	if !ok {
 		return
 	}
 
-	g.P(`gwReq := c.gwc.NewRequest("`, rule.Method, `", "`, rule.Pattern, `")`)
+	generateNewRequest(g, m, rule)
 	fieldsByName := make(map[string]*protogen.Field)
 
 	pathFields := make(map[string]bool)
@@ -192,11 +235,17 @@ func generateParamValues(g *protogen.GeneratedFile, m *protogen.Method) {
 		}
 	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
 		field := "req"
+		bodyMessage := m.Input
 		if rule.Body != "*" {
 			if bodyField, ok := fieldsByName[rule.Body]; ok {
 				field = newStructAccessor([]string{field}, bodyField.GoName)
+				bodyMessage = bodyField.Message
 			}
 		}
-		g.P("gwReq.SetBody(", field, ")")
+		if isHTTPBodyMessage(bodyMessage) {
+			g.P(pkgGatewayClient.Ident("SetHTTPBody"), "(gwReq, ", field, ")")
+		} else {
+			g.P("gwReq.SetBody(", field, ")")
+		}
 	}
 }
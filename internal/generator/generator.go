@@ -42,7 +42,14 @@ func generateClientInterface(g *protogen.GeneratedFile, svc *protogen.Service) {
 			continue
 		}
 
-		if method.Desc.IsStreamingServer() {
+		if isBidiStreamingMethod(method) {
+			// BidiStreamingMethod (context.Context) (gateway.BidiStream[Request, Response], error)"
+			g.P(method.Comments.Leading, method.GoName,
+				"(", pkgContext.Ident("Context"), ") ",
+				"(", pkgGatewayClient.Ident("BidiStream"),
+				"[", getMessageIdentifier(method.Input), ", ", getMessageIdentifier(method.Output), "], error)",
+			)
+		} else if method.Desc.IsStreamingServer() {
 			// StreamingMethod (context.Context, *Request) (<-chan *Response, <-chan error, error)"
 			g.P(method.Comments.Leading, method.GoName,
 				"(", pkgContext.Ident("Context"), ", *", getMessageIdentifier(method.Input), ") ",
@@ -55,6 +62,23 @@ func generateClientInterface(g *protogen.GeneratedFile, svc *protogen.Service) {
 				"(", rpcUnaryReturnType, getMessageIdentifier(method.Output), ", error)",
 			)
 		}
+
+		if isHTTPBodyUploadMethod(method) {
+			// UploadMethodFromReader (context.Context, string, io.Reader) (*Response, error)"
+			g.P("// ", method.GoName, "FromReader is like ", method.GoName, ", but streams the request body")
+			g.P("// from the reader instead of buffering it in memory.")
+			if method.Desc.IsStreamingServer() {
+				g.P(method.GoName, "FromReader",
+					"(", pkgContext.Ident("Context"), ", string, ", pkgIO.Ident("Reader"), ") ",
+					"(", rpcStreamingReturnType, getMessageIdentifier(method.Output), ", <-chan error, error)",
+				)
+			} else {
+				g.P(method.GoName, "FromReader",
+					"(", pkgContext.Ident("Context"), ", string, ", pkgIO.Ident("Reader"), ") ",
+					"(", rpcUnaryReturnType, getMessageIdentifier(method.Output), ", error)",
+				)
+			}
+		}
 	}
 }
 
@@ -81,13 +105,38 @@ func generateClientStruct(g *protogen.GeneratedFile, svc *protogen.Service) {
 			continue
 		}
 
-		if method.Desc.IsStreamingServer() {
+		if isBidiStreamingMethod(method) {
+			generateBidiStreamingMethod(g, structName, method)
+		} else if method.Desc.IsStreamingServer() {
 			generateStreamingServerMethod(g, structName, method)
 		} else {
 			generateUnaryMethod(g, structName, method)
 		}
 		g.P()
+
+		if isHTTPBodyUploadMethod(method) {
+			generateHTTPBodyReaderMethod(g, structName, method)
+			g.P()
+		}
+	}
+}
+
+func generateBidiStreamingMethod(g *protogen.GeneratedFile, receiverName string, m *protogen.Method) {
+	// func (c *client) BidiStreamingMethod(ctx context.Context) (gateway.BidiStream[Request, Response], error) {"
+	g.P("func (c *", receiverName, ") ",
+		m.GoName, "(ctx ", pkgContext.Ident("Context"), ") ",
+		"(", pkgGatewayClient.Ident("BidiStream"),
+		"[", getMessageIdentifier(m.Input), ", ", getMessageIdentifier(m.Output), "], error) {")
+	defer g.P("}")
+
+	rule, ok := getHTTPRule(m)
+	if !ok {
+		return
 	}
+	generateNewRequest(g, m, rule)
+	g.P("return ",
+		pkgGatewayClient.Ident("InvokeBidiStream"),
+		"[", getMessageIdentifier(m.Input), ", ", getMessageIdentifier(m.Output), "](ctx, c.gwc, ", getFullMethodName(m), ", gwReq)")
 }
 
 func generateStreamingServerMethod(g *protogen.GeneratedFile, receiverName string, m *protogen.Method) {
@@ -99,7 +148,7 @@ func generateStreamingServerMethod(g *protogen.GeneratedFile, receiverName strin
 
 	generateParamValues(g, m)
 	g.P("return ",
-		pkgGatewayClient.Ident("DoStreamingRequest"), "[", getMessageIdentifier(m.Output), "](ctx, c.gwc, gwReq)")
+		pkgGatewayClient.Ident("InvokeStream"), "[", getMessageIdentifier(m.Output), "](ctx, c.gwc, ", getFullMethodName(m), ", req, gwReq)")
 }
 
 func generateUnaryMethod(g *protogen.GeneratedFile, receiverName string, m *protogen.Method) {
@@ -111,5 +160,37 @@ func generateUnaryMethod(g *protogen.GeneratedFile, receiverName string, m *prot
 
 	generateParamValues(g, m)
 	g.P("return ",
-		pkgGatewayClient.Ident("DoRequest"), "[", getMessageIdentifier(m.Output), "](ctx, gwReq)")
+		pkgGatewayClient.Ident("Invoke"), "[", getMessageIdentifier(m.Output), "](ctx, c.gwc, ", getFullMethodName(m), ", req, gwReq)")
+}
+
+func generateHTTPBodyReaderMethod(g *protogen.GeneratedFile, receiverName string, m *protogen.Method) {
+	rule, ok := getHTTPRule(m)
+	if !ok {
+		return
+	}
+
+	if m.Desc.IsStreamingServer() {
+		// func (c *client) UploadMethodFromReader(ctx context.Context, contentType string, body io.Reader) (<-chan *Response, <-chan error, error) {"
+		g.P("func (c *", receiverName, ") ",
+			m.GoName, "FromReader(ctx ", pkgContext.Ident("Context"), ", contentType string, body ", pkgIO.Ident("Reader"), ") ",
+			"(", rpcStreamingReturnType, getMessageIdentifier(m.Output), ", <-chan error, error) {")
+		defer g.P("}")
+
+		generateNewRequest(g, m, rule)
+		g.P(pkgGatewayClient.Ident("SetHTTPBodyReader"), "(gwReq, contentType, body)")
+		g.P("return ",
+			pkgGatewayClient.Ident("InvokeStream"), "[", getMessageIdentifier(m.Output), "](ctx, c.gwc, ", getFullMethodName(m), ", nil, gwReq)")
+		return
+	}
+
+	// func (c *client) UploadMethodFromReader(ctx context.Context, contentType string, body io.Reader) (*Response, error) {"
+	g.P("func (c *", receiverName, ") ",
+		m.GoName, "FromReader(ctx ", pkgContext.Ident("Context"), ", contentType string, body ", pkgIO.Ident("Reader"), ") ",
+		"(", rpcUnaryReturnType, getMessageIdentifier(m.Output), ", error) {")
+	defer g.P("}")
+
+	generateNewRequest(g, m, rule)
+	g.P(pkgGatewayClient.Ident("SetHTTPBodyReader"), "(gwReq, contentType, body)")
+	g.P("return ",
+		pkgGatewayClient.Ident("Invoke"), "[", getMessageIdentifier(m.Output), "](ctx, c.gwc, ", getFullMethodName(m), ", nil, gwReq)")
 }
@@ -6,6 +6,7 @@ import "google.golang.org/protobuf/compiler/protogen"
 var (
 	pkgContext = protogen.GoImportPath("context")
 	pkgFmt     = protogen.GoImportPath("fmt")
+	pkgIO      = protogen.GoImportPath("io")
 	pkgNetURL  = protogen.GoImportPath("net/url")
 )
 
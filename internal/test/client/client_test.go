@@ -3,20 +3,30 @@ package test
 import (
 	"context"
 	"encoding/base64"
+	"errors"
+	"io"
 	"net"
+	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/genproto/googleapis/api/httpbody"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 
+	"github.com/akuity/grpc-gateway-client/internal/assets"
 	"github.com/akuity/grpc-gateway-client/internal/test/gen/testv1"
 	"github.com/akuity/grpc-gateway-client/internal/test/server"
+	"github.com/akuity/grpc-gateway-client/internal/test/wsproxy"
 	"github.com/akuity/grpc-gateway-client/pkg/grpc/gateway"
+	gwerrors "github.com/akuity/grpc-gateway-client/pkg/grpc/gateway/errors"
 )
 
 type ClientTestSuite struct {
@@ -45,13 +55,14 @@ func (s *ClientTestSuite) SetupTest() {
 	s.Require().NoError(err)
 
 	marshaller := &runtime.JSONPb{}
-	sseMarshaller := gateway.NewEventStreamMarshaller(marshaller)
+	sseMarshaller := gateway.NewEventStreamMarshaller(marshaller, gateway.WithEventIDSequence())
 	mux := runtime.NewServeMux(
 		runtime.WithMarshalerOption("application/json", marshaller),
 		runtime.WithMarshalerOption("text/event-stream", sseMarshaller),
+		runtime.WithMarshalerOption("application/octet-stream", server.NewHTTPBodyMarshaller(marshaller)),
 	)
 	s.Require().NoError(testv1.RegisterTestServiceHandler(context.TODO(), mux, cc))
-	s.gwSrv = httptest.NewServer(mux)
+	s.gwSrv = httptest.NewServer(wsproxy.New(gateway.KeepAliveEventStreams(mux, 50*time.Millisecond)))
 	s.client = testv1.NewTestServiceGatewayClient(gateway.NewClient(s.gwSrv.URL))
 }
 
@@ -62,6 +73,38 @@ func (s *ClientTestSuite) TestSendInvitation() {
 	s.Require().NoError(err)
 }
 
+func (s *ClientTestSuite) TestSendInvitation_Error() {
+	_, err := s.client.SendInvitation(context.TODO(), &testv1.SendInvitationRequest{})
+	s.Require().Equal(codes.InvalidArgument, status.Code(err))
+
+	var badRequest *gwerrors.BadRequestError
+	s.Require().True(errors.As(err, &badRequest))
+	s.Require().Equal("email", badRequest.Detail.GetFieldViolations()[0].GetField())
+	reason, domain, ok := gwerrors.Reason(err)
+	s.Require().True(ok)
+	s.Require().Equal("EMAIL_REQUIRED", reason)
+	s.Require().Equal("test.akuity.io", domain)
+	httpStatusCode, ok := gwerrors.HTTPStatusCode(err)
+	s.Require().True(ok)
+	s.Require().Equal(http.StatusBadRequest, httpStatusCode)
+}
+
+func (s *ClientTestSuite) TestUploadInvitations() {
+	data := []byte("---\nid: test-1\n")
+	res, err := s.client.UploadInvitations(context.TODO(), &httpbody.HttpBody{
+		ContentType: "application/octet-stream",
+		Data:        data,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(int64(len(data)), res.GetSize())
+}
+
+func (s *ClientTestSuite) TestUploadInvitationsFromReader() {
+	res, err := s.client.UploadInvitationsFromReader(context.TODO(), "application/octet-stream", strings.NewReader(assets.LargeFile))
+	s.Require().NoError(err)
+	s.Require().Equal(int64(len(assets.LargeFile)), res.GetSize())
+}
+
 func (s *ClientTestSuite) TestTrackInvitation() {
 	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
 	defer cancel()
@@ -88,6 +131,29 @@ func (s *ClientTestSuite) TestTrackInvitation() {
 	}
 }
 
+func (s *ClientTestSuite) TestDiscussInvitation() {
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+
+	stream, err := s.client.DiscussInvitation(ctx)
+	s.Require().NoError(err)
+	s.Require().NoError(stream.Send(&testv1.DiscussInvitationRequest{
+		Message: "see you soon",
+	}))
+	s.Require().NoError(stream.CloseSend())
+
+	got := make([]string, 0)
+	for {
+		res, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		s.Require().NoError(err)
+		got = append(got, res.GetMessage())
+	}
+	s.Require().Equal([]string{"see you soon"}, got)
+}
+
 func (s *ClientTestSuite) TestListInvitations() {
 	req := &testv1.ListInvitationsRequest{
 		Query: &testv1.ListInvitationsQuery{
@@ -8,6 +8,7 @@ import (
 	fmt "fmt"
 	gateway "github.com/akuity/grpc-gateway-client/pkg/grpc/gateway"
 	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
+	io "io"
 	url "net/url"
 )
 
@@ -15,7 +16,13 @@ import (
 type TestServiceGatewayClient interface {
 	ListInvitations(context.Context, *ListInvitationsRequest) (*ListInvitationsResponse, error)
 	SendInvitation(context.Context, *SendInvitationRequest) (*SendInvitationResponse, error)
+	AcceptInvitation(context.Context, *AcceptInvitationRequest) (*AcceptInvitationResponse, error)
 	TrackInvitation(context.Context, *TrackInvitationRequest) (<-chan *TrackInvitationResponse, <-chan error, error)
+	UploadInvitations(context.Context, *httpbody.HttpBody) (*UploadInvitationsResponse, error)
+	// UploadInvitationsFromReader is like UploadInvitations, but streams the request body
+	// from the reader instead of buffering it in memory.
+	UploadInvitationsFromReader(context.Context, string, io.Reader) (*UploadInvitationsResponse, error)
+	DiscussInvitation(context.Context) (gateway.BidiStream[DiscussInvitationRequest, DiscussInvitationResponse], error)
 	DownloadInvitations(context.Context, *DownloadInvitationsRequest) (<-chan *httpbody.HttpBody, <-chan error, error)
 	DownloadLargeFile(context.Context, *DownloadLargeFileRequest) (<-chan *httpbody.HttpBody, <-chan error, error)
 }
@@ -38,13 +45,21 @@ func (c *testServiceGatewayClient) ListInvitations(ctx context.Context, req *Lis
 		q.Add(key, fmt.Sprintf("%v", v))
 	}
 	gwReq.SetQueryParamsFromValues(q)
-	return gateway.DoRequest[ListInvitationsResponse](ctx, gwReq)
+	return gateway.Invoke[ListInvitationsResponse](ctx, c.gwc, "/io.akuity.test.v1.TestService/ListInvitations", req, gwReq)
 }
 
 func (c *testServiceGatewayClient) SendInvitation(ctx context.Context, req *SendInvitationRequest) (*SendInvitationResponse, error) {
 	gwReq := c.gwc.NewRequest("POST", "/invitation")
 	gwReq.SetBody(req)
-	return gateway.DoRequest[SendInvitationResponse](ctx, gwReq)
+	return gateway.Invoke[SendInvitationResponse](ctx, c.gwc, "/io.akuity.test.v1.TestService/SendInvitation", req, gwReq)
+}
+
+func (c *testServiceGatewayClient) AcceptInvitation(ctx context.Context, req *AcceptInvitationRequest) (*AcceptInvitationResponse, error) {
+	gwReq := c.gwc.NewRequest("POST", "/invitation/{id}/accept")
+	ctx = gateway.SetIdempotent(ctx)
+	gwReq.SetPathParam("id", fmt.Sprintf("%v", req.Id))
+	gwReq.SetBody(req)
+	return gateway.Invoke[AcceptInvitationResponse](ctx, c.gwc, "/io.akuity.test.v1.TestService/AcceptInvitation", req, gwReq)
 }
 
 func (c *testServiceGatewayClient) TrackInvitation(ctx context.Context, req *TrackInvitationRequest) (<-chan *TrackInvitationResponse, <-chan error, error) {
@@ -55,7 +70,24 @@ func (c *testServiceGatewayClient) TrackInvitation(ctx context.Context, req *Tra
 		q.Add("type", req.Type.String())
 	}
 	gwReq.SetQueryParamsFromValues(q)
-	return gateway.DoStreamingRequest[TrackInvitationResponse](ctx, c.gwc, gwReq)
+	return gateway.InvokeStream[TrackInvitationResponse](ctx, c.gwc, "/io.akuity.test.v1.TestService/TrackInvitation", req, gwReq)
+}
+
+func (c *testServiceGatewayClient) UploadInvitations(ctx context.Context, req *httpbody.HttpBody) (*UploadInvitationsResponse, error) {
+	gwReq := c.gwc.NewRequest("POST", "/upload-invitations")
+	gateway.SetHTTPBody(gwReq, req)
+	return gateway.Invoke[UploadInvitationsResponse](ctx, c.gwc, "/io.akuity.test.v1.TestService/UploadInvitations", req, gwReq)
+}
+
+func (c *testServiceGatewayClient) UploadInvitationsFromReader(ctx context.Context, contentType string, body io.Reader) (*UploadInvitationsResponse, error) {
+	gwReq := c.gwc.NewRequest("POST", "/upload-invitations")
+	gateway.SetHTTPBodyReader(gwReq, contentType, body)
+	return gateway.Invoke[UploadInvitationsResponse](ctx, c.gwc, "/io.akuity.test.v1.TestService/UploadInvitations", nil, gwReq)
+}
+
+func (c *testServiceGatewayClient) DiscussInvitation(ctx context.Context) (gateway.BidiStream[DiscussInvitationRequest, DiscussInvitationResponse], error) {
+	gwReq := c.gwc.NewRequest("POST", "/invitation/discuss")
+	return gateway.InvokeBidiStream[DiscussInvitationRequest, DiscussInvitationResponse](ctx, c.gwc, "/io.akuity.test.v1.TestService/DiscussInvitation", gwReq)
 }
 
 func (c *testServiceGatewayClient) DownloadInvitations(ctx context.Context, req *DownloadInvitationsRequest) (<-chan *httpbody.HttpBody, <-chan error, error) {
@@ -65,10 +97,10 @@ func (c *testServiceGatewayClient) DownloadInvitations(ctx context.Context, req
 		q.Add("type", req.Type.String())
 	}
 	gwReq.SetQueryParamsFromValues(q)
-	return gateway.DoStreamingRequest[httpbody.HttpBody](ctx, c.gwc, gwReq)
+	return gateway.InvokeStream[httpbody.HttpBody](ctx, c.gwc, "/io.akuity.test.v1.TestService/DownloadInvitations", req, gwReq)
 }
 
 func (c *testServiceGatewayClient) DownloadLargeFile(ctx context.Context, req *DownloadLargeFileRequest) (<-chan *httpbody.HttpBody, <-chan error, error) {
 	gwReq := c.gwc.NewRequest("GET", "/download-large-file")
-	return gateway.DoStreamingRequest[httpbody.HttpBody](ctx, c.gwc, gwReq)
+	return gateway.InvokeStream[httpbody.HttpBody](ctx, c.gwc, "/io.akuity.test.v1.TestService/DownloadLargeFile", req, gwReq)
 }
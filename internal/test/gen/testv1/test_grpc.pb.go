@@ -22,7 +22,10 @@ const _ = grpc.SupportPackageIsVersion7
 const (
 	TestService_ListInvitations_FullMethodName     = "/io.akuity.test.v1.TestService/ListInvitations"
 	TestService_SendInvitation_FullMethodName      = "/io.akuity.test.v1.TestService/SendInvitation"
+	TestService_AcceptInvitation_FullMethodName    = "/io.akuity.test.v1.TestService/AcceptInvitation"
 	TestService_TrackInvitation_FullMethodName     = "/io.akuity.test.v1.TestService/TrackInvitation"
+	TestService_UploadInvitations_FullMethodName   = "/io.akuity.test.v1.TestService/UploadInvitations"
+	TestService_DiscussInvitation_FullMethodName   = "/io.akuity.test.v1.TestService/DiscussInvitation"
 	TestService_DownloadInvitations_FullMethodName = "/io.akuity.test.v1.TestService/DownloadInvitations"
 	TestService_DownloadLargeFile_FullMethodName   = "/io.akuity.test.v1.TestService/DownloadLargeFile"
 )
@@ -33,7 +36,10 @@ const (
 type TestServiceClient interface {
 	ListInvitations(ctx context.Context, in *ListInvitationsRequest, opts ...grpc.CallOption) (*ListInvitationsResponse, error)
 	SendInvitation(ctx context.Context, in *SendInvitationRequest, opts ...grpc.CallOption) (*SendInvitationResponse, error)
+	AcceptInvitation(ctx context.Context, in *AcceptInvitationRequest, opts ...grpc.CallOption) (*AcceptInvitationResponse, error)
 	TrackInvitation(ctx context.Context, in *TrackInvitationRequest, opts ...grpc.CallOption) (TestService_TrackInvitationClient, error)
+	UploadInvitations(ctx context.Context, in *httpbody.HttpBody, opts ...grpc.CallOption) (*UploadInvitationsResponse, error)
+	DiscussInvitation(ctx context.Context, opts ...grpc.CallOption) (TestService_DiscussInvitationClient, error)
 	DownloadInvitations(ctx context.Context, in *DownloadInvitationsRequest, opts ...grpc.CallOption) (TestService_DownloadInvitationsClient, error)
 	DownloadLargeFile(ctx context.Context, in *DownloadLargeFileRequest, opts ...grpc.CallOption) (TestService_DownloadLargeFileClient, error)
 }
@@ -64,6 +70,15 @@ func (c *testServiceClient) SendInvitation(ctx context.Context, in *SendInvitati
 	return out, nil
 }
 
+func (c *testServiceClient) AcceptInvitation(ctx context.Context, in *AcceptInvitationRequest, opts ...grpc.CallOption) (*AcceptInvitationResponse, error) {
+	out := new(AcceptInvitationResponse)
+	err := c.cc.Invoke(ctx, TestService_AcceptInvitation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *testServiceClient) TrackInvitation(ctx context.Context, in *TrackInvitationRequest, opts ...grpc.CallOption) (TestService_TrackInvitationClient, error) {
 	stream, err := c.cc.NewStream(ctx, &TestService_ServiceDesc.Streams[0], TestService_TrackInvitation_FullMethodName, opts...)
 	if err != nil {
@@ -96,8 +111,48 @@ func (x *testServiceTrackInvitationClient) Recv() (*TrackInvitationResponse, err
 	return m, nil
 }
 
+func (c *testServiceClient) UploadInvitations(ctx context.Context, in *httpbody.HttpBody, opts ...grpc.CallOption) (*UploadInvitationsResponse, error) {
+	out := new(UploadInvitationsResponse)
+	err := c.cc.Invoke(ctx, TestService_UploadInvitations_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testServiceClient) DiscussInvitation(ctx context.Context, opts ...grpc.CallOption) (TestService_DiscussInvitationClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TestService_ServiceDesc.Streams[1], TestService_DiscussInvitation_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &testServiceDiscussInvitationClient{stream}
+	return x, nil
+}
+
+type TestService_DiscussInvitationClient interface {
+	Send(*DiscussInvitationRequest) error
+	Recv() (*DiscussInvitationResponse, error)
+	grpc.ClientStream
+}
+
+type testServiceDiscussInvitationClient struct {
+	grpc.ClientStream
+}
+
+func (x *testServiceDiscussInvitationClient) Send(m *DiscussInvitationRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *testServiceDiscussInvitationClient) Recv() (*DiscussInvitationResponse, error) {
+	m := new(DiscussInvitationResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *testServiceClient) DownloadInvitations(ctx context.Context, in *DownloadInvitationsRequest, opts ...grpc.CallOption) (TestService_DownloadInvitationsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &TestService_ServiceDesc.Streams[1], TestService_DownloadInvitations_FullMethodName, opts...)
+	stream, err := c.cc.NewStream(ctx, &TestService_ServiceDesc.Streams[2], TestService_DownloadInvitations_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -129,7 +184,7 @@ func (x *testServiceDownloadInvitationsClient) Recv() (*httpbody.HttpBody, error
 }
 
 func (c *testServiceClient) DownloadLargeFile(ctx context.Context, in *DownloadLargeFileRequest, opts ...grpc.CallOption) (TestService_DownloadLargeFileClient, error) {
-	stream, err := c.cc.NewStream(ctx, &TestService_ServiceDesc.Streams[2], TestService_DownloadLargeFile_FullMethodName, opts...)
+	stream, err := c.cc.NewStream(ctx, &TestService_ServiceDesc.Streams[3], TestService_DownloadLargeFile_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -166,7 +221,10 @@ func (x *testServiceDownloadLargeFileClient) Recv() (*httpbody.HttpBody, error)
 type TestServiceServer interface {
 	ListInvitations(context.Context, *ListInvitationsRequest) (*ListInvitationsResponse, error)
 	SendInvitation(context.Context, *SendInvitationRequest) (*SendInvitationResponse, error)
+	AcceptInvitation(context.Context, *AcceptInvitationRequest) (*AcceptInvitationResponse, error)
 	TrackInvitation(*TrackInvitationRequest, TestService_TrackInvitationServer) error
+	UploadInvitations(context.Context, *httpbody.HttpBody) (*UploadInvitationsResponse, error)
+	DiscussInvitation(TestService_DiscussInvitationServer) error
 	DownloadInvitations(*DownloadInvitationsRequest, TestService_DownloadInvitationsServer) error
 	DownloadLargeFile(*DownloadLargeFileRequest, TestService_DownloadLargeFileServer) error
 	mustEmbedUnimplementedTestServiceServer()
@@ -182,9 +240,18 @@ func (UnimplementedTestServiceServer) ListInvitations(context.Context, *ListInvi
 func (UnimplementedTestServiceServer) SendInvitation(context.Context, *SendInvitationRequest) (*SendInvitationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendInvitation not implemented")
 }
+func (UnimplementedTestServiceServer) AcceptInvitation(context.Context, *AcceptInvitationRequest) (*AcceptInvitationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptInvitation not implemented")
+}
 func (UnimplementedTestServiceServer) TrackInvitation(*TrackInvitationRequest, TestService_TrackInvitationServer) error {
 	return status.Errorf(codes.Unimplemented, "method TrackInvitation not implemented")
 }
+func (UnimplementedTestServiceServer) UploadInvitations(context.Context, *httpbody.HttpBody) (*UploadInvitationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadInvitations not implemented")
+}
+func (UnimplementedTestServiceServer) DiscussInvitation(TestService_DiscussInvitationServer) error {
+	return status.Errorf(codes.Unimplemented, "method DiscussInvitation not implemented")
+}
 func (UnimplementedTestServiceServer) DownloadInvitations(*DownloadInvitationsRequest, TestService_DownloadInvitationsServer) error {
 	return status.Errorf(codes.Unimplemented, "method DownloadInvitations not implemented")
 }
@@ -240,6 +307,24 @@ func _TestService_SendInvitation_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TestService_AcceptInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptInvitationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).AcceptInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_AcceptInvitation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).AcceptInvitation(ctx, req.(*AcceptInvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TestService_TrackInvitation_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(TrackInvitationRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -261,6 +346,50 @@ func (x *testServiceTrackInvitationServer) Send(m *TrackInvitationResponse) erro
 	return x.ServerStream.SendMsg(m)
 }
 
+func _TestService_UploadInvitations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(httpbody.HttpBody)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestServiceServer).UploadInvitations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestService_UploadInvitations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestServiceServer).UploadInvitations(ctx, req.(*httpbody.HttpBody))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestService_DiscussInvitation_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TestServiceServer).DiscussInvitation(&testServiceDiscussInvitationServer{stream})
+}
+
+type TestService_DiscussInvitationServer interface {
+	Send(*DiscussInvitationResponse) error
+	Recv() (*DiscussInvitationRequest, error)
+	grpc.ServerStream
+}
+
+type testServiceDiscussInvitationServer struct {
+	grpc.ServerStream
+}
+
+func (x *testServiceDiscussInvitationServer) Send(m *DiscussInvitationResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *testServiceDiscussInvitationServer) Recv() (*DiscussInvitationRequest, error) {
+	m := new(DiscussInvitationRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _TestService_DownloadInvitations_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(DownloadInvitationsRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -318,6 +447,14 @@ var TestService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SendInvitation",
 			Handler:    _TestService_SendInvitation_Handler,
 		},
+		{
+			MethodName: "AcceptInvitation",
+			Handler:    _TestService_AcceptInvitation_Handler,
+		},
+		{
+			MethodName: "UploadInvitations",
+			Handler:    _TestService_UploadInvitations_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -325,6 +462,12 @@ var TestService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _TestService_TrackInvitation_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "DiscussInvitation",
+			Handler:       _TestService_DiscussInvitation_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "DownloadInvitations",
 			Handler:       _TestService_DownloadInvitations_Handler,
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"io"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+)
+
+// NewHTTPBodyMarshaller returns a marshaller that decodes the raw request body
+// into google.api.HttpBody, to be registered for upload content types.
+func NewHTTPBodyMarshaller(marshaller runtime.Marshaler) runtime.Marshaler {
+	return &httpBodyMarshaller{
+		HTTPBodyMarshaler: &runtime.HTTPBodyMarshaler{
+			Marshaler: marshaller,
+		},
+	}
+}
+
+type httpBodyMarshaller struct {
+	*runtime.HTTPBodyMarshaler
+}
+
+func (m *httpBodyMarshaller) NewDecoder(r io.Reader) runtime.Decoder {
+	return runtime.DecoderFunc(func(v interface{}) error {
+		body, ok := v.(*httpbody.HttpBody)
+		if !ok {
+			return m.Marshaler.NewDecoder(r).Decode(v)
+		}
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		body.Data = data
+		return nil
+	})
+}
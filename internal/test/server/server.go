@@ -4,10 +4,17 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"errors"
+	"io"
 	"time"
 
 	"github.com/bufbuild/protoyaml-go"
 	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/akuity/grpc-gateway-client/internal/assets"
 	"github.com/akuity/grpc-gateway-client/internal/test/gen/testv1"
@@ -34,13 +41,44 @@ func (s *testServiceServer) ListInvitations(_ context.Context, req *testv1.ListI
 	}, nil
 }
 
-func (s *testServiceServer) SendInvitation(_ context.Context, req *testv1.SendInvitationRequest) (*testv1.SendInvitationResponse, error) {
+func (s *testServiceServer) SendInvitation(ctx context.Context, req *testv1.SendInvitationRequest) (*testv1.SendInvitationResponse, error) {
+	_ = grpc.SetHeader(ctx, metadata.Pairs("invitation-server", "test"))
+	_ = grpc.SetTrailer(ctx, metadata.Pairs("invitation-status", "done"))
+	if req.GetEmail() == "" {
+		st, err := status.New(codes.InvalidArgument, "email is required").WithDetails(
+			&errdetails.BadRequest{
+				FieldViolations: []*errdetails.BadRequest_FieldViolation{
+					{
+						Field:       "email",
+						Description: "email is required",
+					},
+				},
+			},
+			&errdetails.ErrorInfo{
+				Reason: "EMAIL_REQUIRED",
+				Domain: "test.akuity.io",
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		return nil, st.Err()
+	}
 	return &testv1.SendInvitationResponse{
 		Id: base64.StdEncoding.EncodeToString([]byte(req.Email)),
 	}, nil
 }
 
-func (s *testServiceServer) TrackInvitation(_ *testv1.TrackInvitationRequest, srv testv1.TestService_TrackInvitationServer) error {
+func (s *testServiceServer) AcceptInvitation(_ context.Context, _ *testv1.AcceptInvitationRequest) (*testv1.AcceptInvitationResponse, error) {
+	return &testv1.AcceptInvitationResponse{}, nil
+}
+
+// ExpiredInvitationID is the ID of the invitation whose tracking fails after
+// the first event.
+const ExpiredInvitationID = "expired"
+
+func (s *testServiceServer) TrackInvitation(req *testv1.TrackInvitationRequest, srv testv1.TestService_TrackInvitationServer) error {
+	_ = srv.SetHeader(metadata.Pairs("invitation-server", "test"))
 	eventTypes := []testv1.EventType{
 		testv1.EventType_EVENT_TYPE_SEEN,
 		testv1.EventType_EVENT_TYPE_ACCEPTED,
@@ -49,11 +87,47 @@ func (s *testServiceServer) TrackInvitation(_ *testv1.TrackInvitationRequest, sr
 		_ = srv.Send(&testv1.TrackInvitationResponse{
 			Type: et,
 		})
+		if req.GetId() == ExpiredInvitationID {
+			return status.Error(codes.FailedPrecondition, "invitation expired")
+		}
 		time.Sleep(100 * time.Millisecond)
 	}
 	return nil
 }
 
+func (s *testServiceServer) UploadInvitations(ctx context.Context, req *httpbody.HttpBody) (*testv1.UploadInvitationsResponse, error) {
+	contentType := req.GetContentType()
+	if md, ok := metadata.FromIncomingContext(ctx); ok && contentType == "" {
+		if v := md.Get("grpcgateway-content-type"); len(v) > 0 {
+			contentType = v[0]
+		}
+	}
+	return &testv1.UploadInvitationsResponse{
+		ContentType: contentType,
+		Size:        int64(len(req.GetData())),
+	}, nil
+}
+
+func (s *testServiceServer) DiscussInvitation(srv testv1.TestService_DiscussInvitationServer) error {
+	for {
+		req, err := srv.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if req.GetMessage() == "" {
+			return status.Error(codes.InvalidArgument, "message is required")
+		}
+		if err := srv.Send(&testv1.DiscussInvitationResponse{
+			Message: req.GetMessage(),
+		}); err != nil {
+			return err
+		}
+	}
+}
+
 func (s *testServiceServer) DownloadInvitations(req *testv1.DownloadInvitationsRequest, srv testv1.TestService_DownloadInvitationsServer) error {
 	invitations := []*testv1.Invitation{
 		{
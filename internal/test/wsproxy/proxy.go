@@ -0,0 +1,206 @@
+package wsproxy
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/gorilla/websocket"
+	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/akuity/grpc-gateway-client/pkg/grpc/gateway"
+)
+
+// maxCloseReasonLength is the maximum length of the close reason that fits
+// into a WebSocket control frame.
+const maxCloseReasonLength = 123
+
+var hopHeaders = []string{
+	"Connection",
+	"Upgrade",
+	"Sec-Websocket-Key",
+	"Sec-Websocket-Version",
+	"Sec-Websocket-Extensions",
+	"Sec-Websocket-Protocol",
+}
+
+type proxy struct {
+	h        http.Handler
+	upgrader websocket.Upgrader
+}
+
+// New returns a handler that proxies WebSocket connections to h as a
+// full-duplex stream of newline-delimited JSON messages. Every text frame
+// from the client is written to the request body, until the client sends
+// gateway.WebSocketEndOfSendMessage, and every line of the response is
+// written back as a text frame. Other requests are passed to h.
+func New(h http.Handler) http.Handler {
+	return &proxy{
+		h: h,
+	}
+}
+
+func (p *proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !websocket.IsWebSocketUpgrade(r) {
+		p.h.ServeHTTP(w, r)
+		return
+	}
+
+	conn, err := p.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer func() { _ = conn.Close() }()
+
+	upstreamReq, reqBody := newUpstreamRequest(r)
+	defer func() { _ = reqBody.Close() }()
+	go func() {
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				_ = reqBody.Close()
+				return
+			}
+			if string(data) == gateway.WebSocketEndOfSendMessage {
+				// Keep reading, to handle the close frames.
+				_ = reqBody.Close()
+				continue
+			}
+			if _, err := reqBody.Write(append(data, '\n')); err != nil {
+				return
+			}
+		}
+	}()
+
+	resBody, resWriter := io.Pipe()
+	rw := &responseWriter{
+		header: http.Header{},
+		w:      resWriter,
+	}
+	go func() {
+		p.h.ServeHTTP(rw, upstreamReq)
+		_ = resWriter.Close()
+	}()
+	defer func() { _ = resBody.Close() }()
+
+	br := bufio.NewReader(resBody)
+	for {
+		line, err := br.ReadBytes('\n')
+		if code := rw.statusCode(); code >= http.StatusBadRequest {
+			rest, _ := io.ReadAll(br)
+			writeStatusClose(conn, code, append(line, rest...))
+			return
+		}
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			if err := conn.WriteMessage(websocket.TextMessage, line); err != nil {
+				return
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+	_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+}
+
+func newUpstreamRequest(r *http.Request) (*http.Request, *io.PipeWriter) {
+	q := r.URL.Query()
+	method := q.Get(gateway.WebSocketMethodParam)
+	if method == "" {
+		method = http.MethodPost
+	}
+	q.Del(gateway.WebSocketMethodParam)
+	u := &url.URL{
+		Path:     r.URL.Path,
+		RawQuery: q.Encode(),
+	}
+
+	body, bodyWriter := io.Pipe()
+	req := r.Clone(r.Context())
+	req.Method = method
+	req.URL = u
+	req.RequestURI = u.RequestURI()
+	req.Body = body
+	req.ContentLength = -1
+	for _, h := range hopHeaders {
+		req.Header.Del(h)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	return req, bodyWriter
+}
+
+func writeStatusClose(conn *websocket.Conn, httpStatus int, data []byte) {
+	st := &rpcstatus.Status{
+		Code:    int32(gateway.HTTPStatusToCode(httpStatus)),
+		Message: string(bytes.TrimSpace(data)),
+	}
+	var streamingRes map[string]json.RawMessage
+	if err := json.Unmarshal(data, &streamingRes); err == nil {
+		if rawErr, ok := streamingRes["error"]; ok {
+			data = rawErr
+		}
+		_ = protojson.Unmarshal(data, st)
+	}
+
+	msg := websocket.FormatCloseMessage(gateway.CodeToWebSocketCloseCode(codes.Code(st.GetCode())), truncateCloseReason(st.GetMessage()))
+	_ = conn.WriteMessage(websocket.CloseMessage, msg)
+}
+
+// truncateCloseReason cuts the reason to fit into a close frame, without
+// splitting a rune, since close reasons must be valid UTF-8.
+func truncateCloseReason(reason string) string {
+	if len(reason) <= maxCloseReasonLength {
+		return reason
+	}
+	i := maxCloseReasonLength
+	for i > 0 && !utf8.RuneStart(reason[i]) {
+		i--
+	}
+	return reason[:i]
+}
+
+var (
+	_ http.ResponseWriter = &responseWriter{}
+	_ http.Flusher        = &responseWriter{}
+)
+
+type responseWriter struct {
+	header http.Header
+	w      io.Writer
+
+	mu     sync.Mutex
+	status int
+}
+
+func (w *responseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *responseWriter) WriteHeader(code int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.status == 0 {
+		w.status = code
+	}
+}
+
+func (w *responseWriter) Write(data []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	return w.w.Write(data)
+}
+
+func (w *responseWriter) Flush() {}
+
+func (w *responseWriter) statusCode() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.status
+}
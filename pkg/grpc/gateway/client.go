@@ -1,10 +1,19 @@
 package gateway
 
 import (
+	"context"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+
+	ctxutil "github.com/akuity/grpc-gateway-client/pkg/context"
+	gwerrors "github.com/akuity/grpc-gateway-client/pkg/grpc/gateway/errors"
+	"github.com/akuity/grpc-gateway-client/pkg/http/roundtripper"
 )
 
 type Client interface {
@@ -14,12 +23,28 @@ type Client interface {
 	Unmarshal(data []byte, v interface{}) error
 }
 
+const defaultHTTPBodyChunkSize = 32 * 1024
+
 type client struct {
 	httpClient    *http.Client
 	skipTLSVerify bool
 	rc            *resty.Client
 
-	marshaller *runtime.JSONPb
+	marshaller        *runtime.JSONPb
+	httpBodyChunkSize int
+	streamIdleTimeout time.Duration
+	streamMaxDuration time.Duration
+	httpStatusToCode  func(int) codes.Code
+	retryPolicy       *RetryPolicy
+	grpcTimeoutMargin time.Duration
+	metadataMatcher   OutgoingMetadataMatcher
+	log               logConfig
+	tokenSource       *roundtripper.RefreshingTokenSource
+	perRPCCreds       []credentials.PerRPCCredentials
+	credentialHeaders sync.Map
+
+	unaryInterceptors  []UnaryClientInterceptor
+	streamInterceptors []StreamClientInterceptor
 }
 
 func NewClient(baseURL string, opts ...ClientOption) Client {
@@ -35,13 +60,31 @@ func NewClient(baseURL string, opts ...ClientOption) Client {
 		c.httpClient.Transport = http.DefaultTransport.(*http.Transport).Clone()
 	}
 	setSkipTLSVerify(c.httpClient, c.skipTLSVerify)
+	if c.tokenSource != nil {
+		// Wrap a copy, so that the client of the caller is left as is.
+		hc := *c.httpClient
+		roundtripper.ApplyTokenSource(&hc, c.tokenSource)
+		c.httpClient = &hc
+	}
 
 	if c.marshaller == nil {
 		c.marshaller = &runtime.JSONPb{}
 	}
+	// Copy the marshaller so that the error details can be resolved without
+	// modifying the one of the caller.
+	marshaller := *c.marshaller
+	marshaller.UnmarshalOptions.Resolver = gwerrors.NewTypeResolver(marshaller.UnmarshalOptions.Resolver)
+	c.marshaller = &marshaller
+	if c.metadataMatcher == nil {
+		c.metadataMatcher = DefaultOutgoingMetadataMatcher
+	}
+	if c.httpBodyChunkSize <= 0 {
+		c.httpBodyChunkSize = defaultHTTPBodyChunkSize
+	}
 	c.rc = resty.NewWithClient(c.httpClient).SetBaseURL(baseURL)
 	c.rc.JSONMarshal = c.marshaller.Marshal
 	c.rc.JSONUnmarshal = c.marshaller.Unmarshal
+	c.rc.SetPreRequestHook(c.prepareRequest)
 	return c
 }
 
@@ -49,9 +92,46 @@ func (c *client) NewRequest(method string, url string) *resty.Request {
 	req := c.rc.NewRequest()
 	req.Method = method
 	req.URL = url
+	// Remember the client, so that DoRequest only needs the request.
+	req.SetContext(withClient(context.Background(), c))
 	return req
 }
 
+type clientKey struct {
+	/* explicitly empty */
+}
+
+func withClient(ctx context.Context, c Client) context.Context {
+	return ctxutil.Set(ctx, clientKey{}, c)
+}
+
+// defaultClient serves the requests that were not created by a Client.
+var defaultClient = sync.OnceValue(func() Client {
+	return NewClient("")
+})
+
+// getRequestClient returns the client that created the request.
+func getRequestClient(req *resty.Request) Client {
+	if c, ok := ctxutil.Get[clientKey, Client](req.Context(), clientKey{}); ok {
+		return c
+	}
+	return defaultClient()
+}
+
+// prepareRequest runs on the outgoing request rather than on the resty one, so
+// that every attempt of a retried request sends up to date headers.
+func (c *client) prepareRequest(_ *resty.Client, req *http.Request) error {
+	c.setContextHeaders(req.Context(), req.Header)
+	return c.setCredentialHeaders(req.Context(), req.URL, req.Header)
+}
+
+// setContextHeaders sets the headers derived from the context of a request.
+func (c *client) setContextHeaders(ctx context.Context, header http.Header) {
+	setGRPCTimeout(ctx, header, c.grpcTimeoutMargin)
+	setOutgoingMetadata(ctx, header, c.metadataMatcher)
+	setOutgoingHeader(ctx, header)
+}
+
 func (c *client) Marshal(v interface{}) ([]byte, error) {
 	return c.marshaller.Marshal(v)
 }
@@ -59,3 +139,39 @@ func (c *client) Marshal(v interface{}) ([]byte, error) {
 func (c *client) Unmarshal(data []byte, v interface{}) error {
 	return c.marshaller.Unmarshal(data, v)
 }
+
+// getHTTPBodyChunkSize returns the maximum size of google.api.HttpBody chunks
+// delivered by the streaming requests of the given client.
+func getHTTPBodyChunkSize(c Client) int {
+	if cc, ok := c.(*client); ok {
+		return cc.httpBodyChunkSize
+	}
+	return defaultHTTPBodyChunkSize
+}
+
+// getStreamIdleTimeout returns how long the streams of the given client may go
+// without receiving any data. Zero means no limit.
+func getStreamIdleTimeout(c Client) time.Duration {
+	if cc, ok := c.(*client); ok {
+		return cc.streamIdleTimeout
+	}
+	return 0
+}
+
+// getStreamMaxDuration returns how long the streams of the given client may
+// last. Zero means no limit.
+func getStreamMaxDuration(c Client) time.Duration {
+	if cc, ok := c.(*client); ok {
+		return cc.streamMaxDuration
+	}
+	return 0
+}
+
+// httpStatusCode converts the HTTP status code of a response to gRPC error
+// code with the mapping of the given client.
+func httpStatusCode(c Client, status int) codes.Code {
+	if cc, ok := c.(*client); ok && cc.httpStatusToCode != nil {
+		return cc.httpStatusToCode(status)
+	}
+	return HTTPStatusToCode(status)
+}
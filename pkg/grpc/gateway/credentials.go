@@ -0,0 +1,84 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	ctxutil "github.com/akuity/grpc-gateway-client/pkg/context"
+)
+
+type methodKey struct {
+	/* explicitly empty */
+}
+
+// withMethod stores the full gRPC method name of the call in the context, for
+// the credentials of the client.
+func withMethod(ctx context.Context, method string) context.Context {
+	return ctxutil.Set(ctx, methodKey{}, method)
+}
+
+func getMethod(ctx context.Context) string {
+	method, _ := ctxutil.Get[methodKey, string](ctx, methodKey{})
+	return method
+}
+
+// getAudienceURI returns the URI that gRPC passes to the per-RPC credentials,
+// which is made of the host, without the default port, and of the service of
+// the method, e.g. "https://example.com/package.Service". The method is only
+// known to the calls of the generated clients, so the URI of the requests sent
+// with DoRequest and DoStreamingRequest is the host alone.
+func getAudienceURI(u *url.URL, method string) string {
+	service := method
+	if i := strings.LastIndex(method, "/"); i > 0 {
+		service = method[:i]
+	}
+	return "https://" + strings.TrimSuffix(u.Host, ":443") + service
+}
+
+// isSecureURL reports whether the requests to the URL are sent over TLS.
+func isSecureURL(u *url.URL) bool {
+	return u.Scheme == "https" || u.Scheme == "wss"
+}
+
+// setCredentialHeaders sends the metadata of the per-RPC credentials of the
+// client as headers, failing like gRPC does when they cannot be sent.
+func (c *client) setCredentialHeaders(ctx context.Context, u *url.URL, header http.Header) error {
+	if len(c.perRPCCreds) == 0 {
+		return nil
+	}
+	uri := getAudienceURI(u, getMethod(ctx))
+	for _, creds := range c.perRPCCreds {
+		if creds.RequireTransportSecurity() && !isSecureURL(u) {
+			return status.Error(codes.Unauthenticated, "cannot send secure credentials on an insecure connection")
+		}
+		md, err := creds.GetRequestMetadata(ctx, uri)
+		if err != nil {
+			if _, ok := status.FromError(err); ok {
+				return err
+			}
+			return status.Errorf(codes.Unauthenticated, "per-RPC credentials failed: %v", err)
+		}
+		for key, v := range md {
+			key = strings.ToLower(key)
+			if key == "authorization" {
+				// grpc-gateway forwards the header as authorization metadata.
+				header.Set("Authorization", v)
+				continue
+			}
+			name, ok := DefaultOutgoingMetadataMatcher(key)
+			if !ok {
+				continue
+			}
+			header.Del(name)
+			addMetadataHeader(header, name, key, v)
+			// Remember the header, so that its value is masked in the logs.
+			c.credentialHeaders.Store(http.CanonicalHeaderKey(name), true)
+		}
+	}
+	return nil
+}
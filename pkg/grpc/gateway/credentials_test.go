@@ -0,0 +1,185 @@
+package gateway_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/akuity/grpc-gateway-client/internal/test/gen/testv1"
+	"github.com/akuity/grpc-gateway-client/pkg/grpc/gateway"
+)
+
+type fakePerRPCCredentials struct {
+	md                       map[string]string
+	err                      error
+	requireTransportSecurity bool
+
+	uris []string
+}
+
+func (c *fakePerRPCCredentials) GetRequestMetadata(_ context.Context, uri ...string) (map[string]string, error) {
+	c.uris = append(c.uris, uri...)
+	return c.md, c.err
+}
+
+func (c *fakePerRPCCredentials) RequireTransportSecurity() bool {
+	return c.requireTransportSecurity
+}
+
+func TestPerRPCCredentials(t *testing.T) {
+	var header http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		header = req.Header
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"some-id"}`))
+	}))
+	defer srv.Close()
+	audience := "https://" + strings.TrimPrefix(srv.URL, "http://") + "/io.akuity.test.v1.TestService"
+
+	testSets := map[string]struct {
+		creds          *fakePerRPCCredentials
+		expectedCode   codes.Code
+		expectedHeader http.Header
+		expectedURIs   []string
+	}{
+		"credentials": {
+			creds: &fakePerRPCCredentials{
+				md: map[string]string{
+					"authorization": "Bearer token",
+					"x-tenant":      "akuity",
+					"x-token-bin":   "\x00\x01",
+				},
+			},
+			expectedCode: codes.OK,
+			expectedHeader: http.Header{
+				"Authorization":             {"Bearer token"},
+				"Grpc-Metadata-X-Tenant":    {"akuity"},
+				"Grpc-Metadata-X-Token-Bin": {"AAE="},
+			},
+			expectedURIs: []string{audience},
+		},
+		"credentials requiring transport security": {
+			creds: &fakePerRPCCredentials{
+				md: map[string]string{
+					"authorization": "Bearer token",
+				},
+				requireTransportSecurity: true,
+			},
+			expectedCode: codes.Unauthenticated,
+		},
+		"failing credentials": {
+			creds: &fakePerRPCCredentials{
+				err: errors.New("token expired"),
+			},
+			expectedCode: codes.Unauthenticated,
+			expectedURIs: []string{audience},
+		},
+		"failing credentials with status": {
+			creds: &fakePerRPCCredentials{
+				err: status.Error(codes.PermissionDenied, "denied"),
+			},
+			expectedCode: codes.PermissionDenied,
+			expectedURIs: []string{audience},
+		},
+	}
+	for name, ts := range testSets {
+		t.Run(name, func(t *testing.T) {
+			header = nil
+			client := testv1.NewTestServiceGatewayClient(gateway.NewClient(srv.URL,
+				gateway.WithPerRPCCredentials(ts.creds),
+			))
+
+			_, err := client.SendInvitation(context.TODO(), &testv1.SendInvitationRequest{
+				Email: "test@test.com",
+			})
+			require.Equal(t, ts.expectedCode, status.Code(err))
+			require.Equal(t, ts.expectedURIs, ts.creds.uris)
+			if ts.expectedCode != codes.OK {
+				// Nothing is sent without the credentials.
+				require.Nil(t, header)
+				return
+			}
+			for k, v := range ts.expectedHeader {
+				require.Equal(t, v, header.Values(k), k)
+			}
+		})
+	}
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestPerRPCCredentials_AudienceURI(t *testing.T) {
+	hc := &http.Client{
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(`{"id":"some-id"}`)),
+				Request:    req,
+			}, nil
+		}),
+	}
+	sendInvitation := func(c gateway.Client) error {
+		_, err := testv1.NewTestServiceGatewayClient(c).SendInvitation(context.TODO(), &testv1.SendInvitationRequest{
+			Email: "test@test.com",
+		})
+		return err
+	}
+
+	testSets := map[string]struct {
+		baseURL     string
+		call        func(c gateway.Client) error
+		expectedURI string
+	}{
+		"default port": {
+			baseURL:     "https://example.com:443",
+			call:        sendInvitation,
+			expectedURI: "https://example.com/io.akuity.test.v1.TestService",
+		},
+		"custom port": {
+			baseURL:     "https://example.com:8443",
+			call:        sendInvitation,
+			expectedURI: "https://example.com:8443/io.akuity.test.v1.TestService",
+		},
+		"request without method": {
+			baseURL: "https://example.com",
+			call: func(c gateway.Client) error {
+				_, err := gateway.DoRequest[testv1.SendInvitationResponse](context.TODO(), c.NewRequest(http.MethodPost, "/invitation"))
+				return err
+			},
+			expectedURI: "https://example.com",
+		},
+	}
+	for name, ts := range testSets {
+		t.Run(name, func(t *testing.T) {
+			creds := &fakePerRPCCredentials{}
+			c := gateway.NewClient(ts.baseURL, gateway.WithHTTPClient(hc), gateway.WithPerRPCCredentials(creds))
+
+			require.NoError(t, ts.call(c))
+			require.Equal(t, []string{ts.expectedURI}, creds.uris)
+		})
+	}
+}
+
+func TestPerRPCCredentials_WebSocket(t *testing.T) {
+	client := testv1.NewTestServiceGatewayClient(gateway.NewClient("http://localhost",
+		gateway.WithPerRPCCredentials(&fakePerRPCCredentials{
+			requireTransportSecurity: true,
+		}),
+	))
+
+	_, err := client.DiscussInvitation(context.TODO())
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -0,0 +1,56 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// grpcTimeoutHeader is turned into a server-side deadline by grpc-gateway.
+const grpcTimeoutHeader = "Grpc-Timeout"
+
+// maxGRPCTimeoutValue is the largest value of the Grpc-Timeout header, which
+// is limited to 8 digits.
+const maxGRPCTimeoutValue = 100_000_000 - 1
+
+var grpcTimeoutUnits = []struct {
+	unit   time.Duration
+	suffix string
+}{
+	{time.Nanosecond, "n"},
+	{time.Microsecond, "u"},
+	{time.Millisecond, "m"},
+	{time.Second, "S"},
+	{time.Minute, "M"},
+	{time.Hour, "H"},
+}
+
+// setGRPCTimeout sends the time left until the deadline of the request
+// context, less the margin, so that the gateway and the backend give up when
+// the client does. Timeouts set explicitly by the caller are kept.
+func setGRPCTimeout(ctx context.Context, header http.Header, margin time.Duration) {
+	deadline, ok := ctx.Deadline()
+	if !ok || header.Get(grpcTimeoutHeader) != "" {
+		return
+	}
+	header.Set(grpcTimeoutHeader, encodeGRPCTimeout(time.Until(deadline)-margin))
+}
+
+// encodeGRPCTimeout formats the timeout with the finest unit that fits in the
+// header, rounding up.
+func encodeGRPCTimeout(t time.Duration) string {
+	if t <= 0 {
+		return "0n"
+	}
+	for _, u := range grpcTimeoutUnits {
+		v := int64(t / u.unit)
+		if t%u.unit > 0 {
+			v++
+		}
+		if v <= maxGRPCTimeoutValue {
+			return strconv.FormatInt(v, 10) + u.suffix
+		}
+	}
+	return strconv.FormatInt(maxGRPCTimeoutValue, 10) + "H"
+}
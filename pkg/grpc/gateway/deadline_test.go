@@ -0,0 +1,141 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeGRPCTimeout(t *testing.T) {
+	testSets := map[string]struct {
+		input    time.Duration
+		expected string
+	}{
+		"expired": {
+			input:    -time.Second,
+			expected: "0n",
+		},
+		"nanoseconds": {
+			input:    1500 * time.Nanosecond,
+			expected: "1500n",
+		},
+		"rounded up": {
+			input:    100*time.Millisecond + time.Nanosecond,
+			expected: "100001u",
+		},
+		"seconds": {
+			input:    30 * time.Hour,
+			expected: "108000S",
+		},
+		"hours": {
+			input:    2_000_000 * time.Hour,
+			expected: "2000000H",
+		},
+	}
+	for name, ts := range testSets {
+		t.Run(name, func(t *testing.T) {
+			actual := encodeGRPCTimeout(ts.input)
+			require.Equal(t, ts.expected, actual)
+			if ts.input > 0 {
+				// The timeout must round-trip through grpc-gateway.
+				timeout, err := parseGRPCTimeout(actual)
+				require.NoError(t, err)
+				require.GreaterOrEqual(t, timeout, ts.input-time.Second)
+			}
+		})
+	}
+}
+
+func TestGRPCTimeout(t *testing.T) {
+	testSets := map[string]struct {
+		timeout  time.Duration
+		margin   time.Duration
+		header   string
+		expected func(t *testing.T, actual string)
+	}{
+		"no deadline": {
+			expected: func(t *testing.T, actual string) {
+				require.Empty(t, actual)
+			},
+		},
+		"deadline": {
+			timeout: time.Minute,
+			expected: func(t *testing.T, actual string) {
+				timeout, err := parseGRPCTimeout(actual)
+				require.NoError(t, err)
+				require.InDelta(t, time.Minute, timeout, float64(time.Second))
+			},
+		},
+		"margin": {
+			timeout: time.Minute,
+			margin:  10 * time.Second,
+			expected: func(t *testing.T, actual string) {
+				timeout, err := parseGRPCTimeout(actual)
+				require.NoError(t, err)
+				require.InDelta(t, 50*time.Second, timeout, float64(time.Second))
+			},
+		},
+		"explicit header": {
+			timeout: time.Minute,
+			header:  "5S",
+			expected: func(t *testing.T, actual string) {
+				require.Equal(t, "5S", actual)
+			},
+		},
+	}
+	for name, ts := range testSets {
+		t.Run(name, func(t *testing.T) {
+			var actual []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				actual = append(actual, r.Header.Get(grpcTimeoutHeader))
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer srv.Close()
+			c := NewClient(srv.URL, WithGRPCTimeoutMargin(ts.margin))
+
+			ctx := context.Background()
+			if ts.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, ts.timeout)
+				defer cancel()
+			}
+			newRequest := func() *resty.Request {
+				req := c.NewRequest(http.MethodGet, "/")
+				if ts.header != "" {
+					req.SetHeader(grpcTimeoutHeader, ts.header)
+				}
+				return req
+			}
+			_, err := DoRequest[struct{}](ctx, newRequest())
+			require.NoError(t, err)
+			resCh, errCh, err := DoStreamingRequest[struct{}](ctx, c, newRequest())
+			require.NoError(t, err)
+			for range resCh {
+			}
+			require.NoError(t, <-errCh)
+
+			require.Len(t, actual, 2)
+			for _, v := range actual {
+				ts.expected(t, v)
+			}
+		})
+	}
+}
+
+// parseGRPCTimeout parses the header the way grpc-gateway does.
+func parseGRPCTimeout(v string) (time.Duration, error) {
+	ctx, err := runtime.AnnotateContext(context.Background(), runtime.NewServeMux(),
+		&http.Request{Header: http.Header{grpcTimeoutHeader: []string{v}}}, "/test.v1.Test/Method")
+	if err != nil {
+		return 0, err
+	}
+	deadline, _ := ctx.Deadline()
+	return time.Until(deadline), nil
+}
@@ -0,0 +1,187 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const defaultDownloadMaxRetries = 3
+
+// DownloadProgressFunc is called whenever a chunk of the body is written.
+// total is -1 if the size of the body is unknown.
+type DownloadProgressFunc func(written, total int64)
+
+type DownloadOption func(*downloadOptions)
+
+type downloadOptions struct {
+	maxRetries int
+	progress   DownloadProgressFunc
+}
+
+// WithDownloadMaxRetries sets how many times an interrupted download is
+// resumed before giving up. Defaults to 3.
+func WithDownloadMaxRetries(n int) DownloadOption {
+	return func(o *downloadOptions) {
+		o.maxRetries = n
+	}
+}
+
+func WithDownloadProgress(fn DownloadProgressFunc) DownloadOption {
+	return func(o *downloadOptions) {
+		o.progress = fn
+	}
+}
+
+// Download writes the google.api.HttpBody response of the request to w and
+// returns the number of bytes written. When the transfer is interrupted, the
+// download is resumed with a Range request if the server supports it, or
+// restarted from the beginning otherwise.
+func Download(ctx context.Context, c Client, req *resty.Request, w io.WriterAt, opts ...DownloadOption) (int64, error) {
+	o := &downloadOptions{
+		maxRetries: defaultDownloadMaxRetries,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	d := &download{
+		ctx:   ctx,
+		c:     c,
+		req:   req,
+		url:   req.URL,
+		w:     w,
+		opts:  o,
+		total: -1,
+	}
+	var err error
+	for attempt := 0; attempt <= o.maxRetries; attempt++ {
+		var retryable bool
+		retryable, err = d.fetch()
+		if err == nil || !retryable || ctx.Err() != nil {
+			break
+		}
+	}
+	return d.written, err
+}
+
+type download struct {
+	ctx  context.Context
+	c    Client
+	req  *resty.Request
+	url  string
+	w    io.WriterAt
+	opts *downloadOptions
+
+	written   int64
+	total     int64
+	resumable bool
+	validator string
+}
+
+// fetch requests the rest of the body and writes it to w. It reports whether
+// the returned error is caused by an interrupted transfer.
+func (d *download) fetch() (bool, error) {
+	// resty rewrites the URL with the path and query params on every send.
+	d.req.URL = d.url
+	d.req.Header.Del("Range")
+	d.req.Header.Del("If-Range")
+	if d.written > 0 && d.resumable {
+		d.req.SetHeader("Range", fmt.Sprintf("bytes=%d-", d.written))
+		if d.validator != "" {
+			d.req.SetHeader("If-Range", d.validator)
+		}
+	}
+
+	res, err := doRawRequest(d.ctx, d.req)
+	if err != nil {
+		return true, err
+	}
+	if res.IsError() {
+		return false, wrapStreamingResponseError(d.c, res)
+	}
+	body := res.RawBody()
+	defer func() { _ = body.Close() }()
+
+	if res.StatusCode() == http.StatusPartialContent {
+		start, total, ok := parseContentRange(res.Header().Get("Content-Range"))
+		if !ok || start != d.written {
+			return false, fmt.Errorf("unexpected content range: %q", res.Header().Get("Content-Range"))
+		}
+		d.total = total
+	} else {
+		// The server sent the whole body, either because it does not support
+		// ranges or because the body has changed since the last attempt.
+		d.written = 0
+		d.total = res.RawResponse.ContentLength
+		d.resumable = res.Header().Get("Accept-Ranges") == "bytes"
+		d.validator = getRangeValidator(res.Header())
+	}
+
+	buf := make([]byte, getHTTPBodyChunkSize(d.c))
+	for {
+		n, err := body.Read(buf)
+		if n > 0 {
+			if _, err := d.w.WriteAt(buf[:n], d.written); err != nil {
+				return false, fmt.Errorf("write body: %w", err)
+			}
+			d.written += int64(n)
+			if d.opts.progress != nil {
+				d.opts.progress(d.written, d.total)
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			if d.total >= 0 && d.written < d.total {
+				return true, newTransportError(d.ctx, "read body", io.ErrUnexpectedEOF)
+			}
+			return false, nil
+		}
+		if err != nil {
+			return true, newTransportError(d.ctx, "read body", err)
+		}
+	}
+}
+
+// getRangeValidator returns the validator for the If-Range header. Weak
+// entity tags cannot be used for range requests.
+func getRangeValidator(header http.Header) string {
+	if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+	return header.Get("Last-Modified")
+}
+
+// parseContentRange parses the start offset and the complete length from the
+// Content-Range header, e.g. "bytes 100-199/1000".
+func parseContentRange(v string) (int64, int64, bool) {
+	v, ok := strings.CutPrefix(v, "bytes ")
+	if !ok {
+		return 0, 0, false
+	}
+	rng, size, ok := strings.Cut(v, "/")
+	if !ok {
+		return 0, 0, false
+	}
+	first, _, ok := strings.Cut(rng, "-")
+	if !ok {
+		return 0, 0, false
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	if size == "*" {
+		return start, -1, true
+	}
+	total, err := strconv.ParseInt(size, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return start, total, true
+}
@@ -0,0 +1,177 @@
+package gateway_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/akuity/grpc-gateway-client/internal/assets"
+	"github.com/akuity/grpc-gateway-client/pkg/grpc/gateway"
+)
+
+// flakyServer serves the data, but drops the connection in the middle of
+// the body for the first few requests.
+type flakyServer struct {
+	data          []byte
+	supportsRange bool
+	failures      int
+	dropAfter     int
+
+	mu     sync.Mutex
+	ranges []string
+}
+
+func (s *flakyServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	attempt := len(s.ranges)
+	s.ranges = append(s.ranges, req.Header.Get("Range"))
+	s.mu.Unlock()
+
+	if attempt < s.failures {
+		w = &droppingResponseWriter{
+			ResponseWriter: w,
+			limit:          s.dropAfter,
+		}
+	}
+	if s.supportsRange {
+		w.Header().Set("ETag", `"v1"`)
+		http.ServeContent(w, req, "", time.Time{}, bytes.NewReader(s.data))
+		return
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(s.data)))
+	_, _ = w.Write(s.data)
+}
+
+func (s *flakyServer) requestedRanges() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.ranges...)
+}
+
+type droppingResponseWriter struct {
+	http.ResponseWriter
+	limit   int
+	written int
+}
+
+func (w *droppingResponseWriter) Write(data []byte) (int, error) {
+	if w.written+len(data) <= w.limit {
+		w.written += len(data)
+		return w.ResponseWriter.Write(data)
+	}
+	_, _ = w.ResponseWriter.Write(data[:w.limit-w.written])
+	w.ResponseWriter.(http.Flusher).Flush()
+	panic(http.ErrAbortHandler)
+}
+
+type memoryWriterAt struct {
+	mu  sync.Mutex
+	buf []byte
+}
+
+func (w *memoryWriterAt) WriteAt(data []byte, off int64) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if end := int(off) + len(data); end > len(w.buf) {
+		w.buf = append(w.buf, make([]byte, end-len(w.buf))...)
+	}
+	return copy(w.buf[off:], data), nil
+}
+
+func TestDownload(t *testing.T) {
+	data := []byte(assets.LargeFile)
+	testSets := map[string]struct {
+		srv            *flakyServer
+		opts           []gateway.DownloadOption
+		expectedRanges []string
+		errExpected    bool
+	}{
+		"without failures": {
+			srv: &flakyServer{
+				data:          data,
+				supportsRange: true,
+			},
+			expectedRanges: []string{""},
+		},
+		"resume with range requests": {
+			srv: &flakyServer{
+				data:          data,
+				supportsRange: true,
+				failures:      2,
+				dropAfter:     10000,
+			},
+			expectedRanges: []string{"", "bytes=10000-", "bytes=20000-"},
+		},
+		"restart without range support": {
+			srv: &flakyServer{
+				data:      data,
+				failures:  1,
+				dropAfter: 10000,
+			},
+			expectedRanges: []string{"", ""},
+		},
+		"give up after max retries": {
+			srv: &flakyServer{
+				data:          data,
+				supportsRange: true,
+				failures:      10,
+				dropAfter:     100,
+			},
+			opts: []gateway.DownloadOption{
+				gateway.WithDownloadMaxRetries(2),
+			},
+			expectedRanges: []string{"", "bytes=100-", "bytes=200-"},
+			errExpected:    true,
+		},
+	}
+	for name, ts := range testSets {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(ts.srv)
+			defer srv.Close()
+
+			var progress []int64
+			opts := append(ts.opts, gateway.WithDownloadProgress(func(written, total int64) {
+				require.Equal(t, int64(len(data)), total)
+				progress = append(progress, written)
+			}))
+
+			client := gateway.NewClient(srv.URL)
+			w := &memoryWriterAt{}
+			written, err := gateway.Download(context.TODO(), client, client.NewRequest(http.MethodGet, "/file"), w, opts...)
+			require.Equal(t, ts.expectedRanges, ts.srv.requestedRanges())
+			if ts.errExpected {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, int64(len(data)), written)
+			require.Equal(t, data, w.buf)
+			require.Equal(t, int64(len(data)), progress[len(progress)-1])
+		})
+	}
+}
+
+func TestDownload_Error(t *testing.T) {
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":5,"message":"file not found"}`))
+	}))
+	defer srv.Close()
+
+	client := gateway.NewClient(srv.URL)
+	_, err := gateway.Download(context.TODO(), client, client.NewRequest(http.MethodGet, "/file"), &memoryWriterAt{})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.Equal(t, 1, attempts)
+}
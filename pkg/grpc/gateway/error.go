@@ -0,0 +1,96 @@
+package gateway
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxHTTPErrorBodyLength is the maximum length of the body snippet of an
+// HTTPError.
+const maxHTTPErrorBodyLength = 256
+
+// httpErrorHeaders are the headers of the response that are kept in an
+// HTTPError, since they help to tell which proxy failed and why.
+var httpErrorHeaders = []string{
+	"Content-Type",
+	"Retry-After",
+	"Server",
+	"Via",
+	"WWW-Authenticate",
+	"X-Request-Id",
+}
+
+var (
+	htmlTagRegexp    = regexp.MustCompile(`(?s)<(script|style)[^>]*>.*?</(script|style)>|<[^>]*>`)
+	whitespaceRegexp = regexp.MustCompile(`\s+`)
+)
+
+var _ interface {
+	error
+	GRPCStatus() *status.Status
+} = &HTTPError{}
+
+// HTTPError is an error response without a gRPC status, such as the error
+// pages of proxies and load balancers.
+type HTTPError struct {
+	// StatusCode is the status code of the HTTP response.
+	StatusCode int
+	// Code is the gRPC error code that StatusCode maps to.
+	Code codes.Code
+	// Header holds the headers of the response that help to diagnose it.
+	Header http.Header
+	// Body is the beginning of the response body, as text.
+	Body string
+}
+
+func newHTTPError(statusCode int, code codes.Code, header http.Header, data []byte) *HTTPError {
+	kept := http.Header{}
+	for _, k := range httpErrorHeaders {
+		if v := header.Values(k); len(v) > 0 {
+			kept[k] = v
+		}
+	}
+	return &HTTPError{
+		StatusCode: statusCode,
+		Code:       code,
+		Header:     kept,
+		Body:       getBodySnippet(header.Get("Content-Type"), data),
+	}
+}
+
+func (e *HTTPError) Error() string {
+	msg := fmt.Sprintf("unexpected HTTP status %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Body != "" {
+		msg += ": " + e.Body
+	}
+	return msg
+}
+
+func (e *HTTPError) GRPCStatus() *status.Status {
+	return status.New(e.Code, e.Error())
+}
+
+// getBodySnippet returns the beginning of the body as a single line of text.
+func getBodySnippet(contentType string, data []byte) string {
+	body := string(data)
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType == "text/html" {
+		body = htmlTagRegexp.ReplaceAllString(body, " ")
+	}
+	body = strings.TrimSpace(whitespaceRegexp.ReplaceAllString(body, " "))
+	body = strings.ToValidUTF8(body, "")
+	if len(body) <= maxHTTPErrorBodyLength {
+		return body
+	}
+	end := maxHTTPErrorBodyLength
+	for end > 0 && !utf8.RuneStart(body[end]) {
+		end--
+	}
+	return body[:end] + "..."
+}
@@ -0,0 +1,111 @@
+package gateway_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/akuity/grpc-gateway-client/internal/test/gen/testv1"
+	"github.com/akuity/grpc-gateway-client/pkg/grpc/gateway"
+)
+
+func TestHTTPError(t *testing.T) {
+	testSets := map[string]struct {
+		statusCode     int
+		header         http.Header
+		body           string
+		expectedCode   codes.Code
+		expectedBody   string
+		expectedHeader http.Header
+	}{
+		"html page": {
+			statusCode: http.StatusBadGateway,
+			header: http.Header{
+				"Content-Type": []string{"text/html"},
+				"Server":       []string{"nginx"},
+				"Set-Cookie":   []string{"session=secret"},
+			},
+			body: "<html>\n<head><title>502 Bad Gateway</title><style>body { color: red; }</style></head>\n" +
+				"<body><center><h1>502 Bad Gateway</h1></center></body>\n</html>",
+			expectedCode: codes.Unavailable,
+			expectedBody: "502 Bad Gateway 502 Bad Gateway",
+			expectedHeader: http.Header{
+				"Content-Type": []string{"text/html"},
+				"Server":       []string{"nginx"},
+			},
+		},
+		"plain text": {
+			statusCode: http.StatusServiceUnavailable,
+			header: http.Header{
+				"Content-Type": []string{"text/plain"},
+				"Retry-After":  []string{"10"},
+			},
+			body:         "upstream connect error or disconnect/reset before headers\n",
+			expectedCode: codes.Unavailable,
+			expectedBody: "upstream connect error or disconnect/reset before headers",
+			expectedHeader: http.Header{
+				"Content-Type": []string{"text/plain"},
+				"Retry-After":  []string{"10"},
+			},
+		},
+		"long body": {
+			statusCode: http.StatusInternalServerError,
+			header: http.Header{
+				"Content-Type": []string{"text/plain"},
+			},
+			body:         strings.Repeat("a", 1000),
+			expectedCode: codes.Internal,
+			expectedBody: strings.Repeat("a", 256) + "...",
+			expectedHeader: http.Header{
+				"Content-Type": []string{"text/plain"},
+			},
+		},
+		"json without status": {
+			statusCode: http.StatusUnauthorized,
+			header: http.Header{
+				"Content-Type": []string{"application/json"},
+			},
+			body:         `{"message":"invalid token"}`,
+			expectedCode: codes.Unauthenticated,
+			expectedBody: `{"message":"invalid token"}`,
+			expectedHeader: http.Header{
+				"Content-Type": []string{"application/json"},
+			},
+		},
+	}
+	for name, ts := range testSets {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				for k, v := range ts.header {
+					w.Header()[k] = v
+				}
+				w.WriteHeader(ts.statusCode)
+				_, _ = w.Write([]byte(ts.body))
+			}))
+			defer srv.Close()
+			c := gateway.NewClient(srv.URL)
+
+			_, unaryErr := gateway.DoRequest[testv1.SendInvitationResponse](context.TODO(), c.NewRequest(http.MethodPost, "/"))
+			_, _, streamingErr := gateway.DoStreamingRequest[testv1.TrackInvitationResponse](context.TODO(), c, c.NewRequest(http.MethodGet, "/"))
+			for _, err := range []error{unaryErr, streamingErr} {
+				var httpErr *gateway.HTTPError
+				require.True(t, errors.As(err, &httpErr))
+				require.Equal(t, ts.statusCode, httpErr.StatusCode)
+				require.Equal(t, ts.expectedCode, httpErr.Code)
+				require.Equal(t, ts.expectedBody, httpErr.Body)
+				require.Equal(t, ts.expectedHeader, httpErr.Header)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, ts.expectedCode, st.Code())
+			}
+		})
+	}
+}
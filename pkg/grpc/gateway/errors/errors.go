@@ -0,0 +1,208 @@
+// Package errors provides typed access to the errors returned by the gateway
+// client, including the google.rpc error details they carry.
+package errors
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+var _ interface {
+	error
+	GRPCStatus() *status.Status
+} = &Error{}
+
+// Error is a gRPC status returned by the gateway, along with the HTTP response
+// that carried it.
+type Error struct {
+	// HTTPStatusCode is the status code of the HTTP response. It is zero for
+	// the errors sent in the middle of a stream.
+	HTTPStatusCode int
+	// Header holds the headers of the HTTP response, if any.
+	Header http.Header
+
+	status *status.Status
+}
+
+func New(st *status.Status, httpStatusCode int, header http.Header) *Error {
+	return &Error{
+		HTTPStatusCode: httpStatusCode,
+		Header:         header,
+		status:         st,
+	}
+}
+
+func (e *Error) Error() string {
+	return e.status.Err().Error()
+}
+
+func (e *Error) GRPCStatus() *status.Status {
+	return e.status
+}
+
+// As allows errors.As to extract the details of the error as a DetailError,
+// e.g.
+//
+//	var badRequest *errors.BadRequestError
+//	if errors.As(err, &badRequest) { ... }
+func (e *Error) As(target any) bool {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Pointer {
+		return false
+	}
+	d, ok := reflect.New(v.Elem().Type().Elem()).Interface().(detailSetter)
+	if !ok || !d.setDetail(e) {
+		return false
+	}
+	v.Elem().Set(reflect.ValueOf(d))
+	return true
+}
+
+type detailSetter interface {
+	setDetail(*Error) bool
+}
+
+// DetailError is an Error with a detail of type D.
+type DetailError[D proto.Message] struct {
+	Err    *Error
+	Detail D
+}
+
+type (
+	BadRequestError          = DetailError[*errdetails.BadRequest]
+	ErrorInfoError           = DetailError[*errdetails.ErrorInfo]
+	RetryInfoError           = DetailError[*errdetails.RetryInfo]
+	QuotaFailureError        = DetailError[*errdetails.QuotaFailure]
+	PreconditionFailureError = DetailError[*errdetails.PreconditionFailure]
+)
+
+func (e *DetailError[D]) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DetailError[D]) GRPCStatus() *status.Status {
+	return e.Err.GRPCStatus()
+}
+
+func (e *DetailError[D]) Unwrap() error {
+	return e.Err
+}
+
+func (e *DetailError[D]) setDetail(err *Error) bool {
+	for _, detail := range err.status.Details() {
+		if d, ok := detail.(D); ok {
+			e.Err = err
+			e.Detail = d
+			return true
+		}
+	}
+	return false
+}
+
+// HTTPStatusCode returns the HTTP status code of the response the error was
+// read from.
+func HTTPStatusCode(err error) (int, bool) {
+	var e *Error
+	if !errors.As(err, &e) {
+		return 0, false
+	}
+	return e.HTTPStatusCode, true
+}
+
+// Header returns the headers of the response the error was read from.
+func Header(err error) (http.Header, bool) {
+	var e *Error
+	if !errors.As(err, &e) {
+		return nil, false
+	}
+	return e.Header, true
+}
+
+// Reason returns the reason and the domain of the google.rpc.ErrorInfo detail
+// of the error.
+func Reason(err error) (reason, domain string, ok bool) {
+	var e *ErrorInfoError
+	if !errors.As(err, &e) {
+		return "", "", false
+	}
+	return e.Detail.GetReason(), e.Detail.GetDomain(), true
+}
+
+// RetryDelay returns the delay of the google.rpc.RetryInfo detail of the error.
+func RetryDelay(err error) (time.Duration, bool) {
+	var e *RetryInfoError
+	if !errors.As(err, &e) || e.Detail.GetRetryDelay() == nil {
+		return 0, false
+	}
+	return e.Detail.GetRetryDelay().AsDuration(), true
+}
+
+// TypeResolver resolves the message types of protobuf Any values, such as the
+// details of a google.rpc.Status.
+type TypeResolver interface {
+	protoregistry.MessageTypeResolver
+	protoregistry.ExtensionTypeResolver
+}
+
+// NewTypeResolver returns a resolver that falls back to the google.rpc error
+// detail types for the messages that r does not know. A nil r stands for
+// protoregistry.GlobalTypes.
+func NewTypeResolver(r TypeResolver) TypeResolver {
+	if r == nil {
+		r = protoregistry.GlobalTypes
+	}
+	return &typeResolver{
+		TypeResolver: r,
+	}
+}
+
+var detailTypes = newDetailTypes()
+
+func newDetailTypes() *protoregistry.Types {
+	types := &protoregistry.Types{}
+	for _, m := range []proto.Message{
+		&errdetails.ErrorInfo{},
+		&errdetails.RetryInfo{},
+		&errdetails.DebugInfo{},
+		&errdetails.QuotaFailure{},
+		&errdetails.PreconditionFailure{},
+		&errdetails.BadRequest{},
+		&errdetails.RequestInfo{},
+		&errdetails.ResourceInfo{},
+		&errdetails.Help{},
+		&errdetails.LocalizedMessage{},
+	} {
+		if err := types.RegisterMessage(m.ProtoReflect().Type()); err != nil {
+			panic(err)
+		}
+	}
+	return types
+}
+
+type typeResolver struct {
+	TypeResolver
+}
+
+func (r *typeResolver) FindMessageByName(name protoreflect.FullName) (protoreflect.MessageType, error) {
+	mt, err := r.TypeResolver.FindMessageByName(name)
+	if errors.Is(err, protoregistry.NotFound) {
+		return detailTypes.FindMessageByName(name)
+	}
+	return mt, err
+}
+
+func (r *typeResolver) FindMessageByURL(url string) (protoreflect.MessageType, error) {
+	mt, err := r.TypeResolver.FindMessageByURL(url)
+	if errors.Is(err, protoregistry.NotFound) {
+		return detailTypes.FindMessageByURL(url)
+	}
+	return mt, err
+}
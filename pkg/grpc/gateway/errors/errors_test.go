@@ -0,0 +1,114 @@
+package errors_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	gwerrors "github.com/akuity/grpc-gateway-client/pkg/grpc/gateway/errors"
+)
+
+func newError(t *testing.T) error {
+	st, err := status.New(codes.ResourceExhausted, "quota exceeded").WithDetails(
+		&errdetails.ErrorInfo{
+			Reason: "QUOTA_EXCEEDED",
+			Domain: "test.akuity.io",
+		},
+		&errdetails.RetryInfo{
+			RetryDelay: durationpb.New(3 * time.Second),
+		},
+		&errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailure_Violation{
+				{
+					Subject: "invitations",
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	return gwerrors.New(st, http.StatusTooManyRequests, http.Header{
+		"Content-Type": []string{"application/json"},
+	})
+}
+
+func TestError(t *testing.T) {
+	testSets := map[string]func(t *testing.T) error{
+		"error": newError,
+		"wrapped error": func(t *testing.T) error {
+			return fmt.Errorf("send invitation: %w", newError(t))
+		},
+	}
+	for name, newErrorFunc := range testSets {
+		t.Run(name, func(t *testing.T) {
+			err := newErrorFunc(t)
+
+			httpStatusCode, ok := gwerrors.HTTPStatusCode(err)
+			require.True(t, ok)
+			require.Equal(t, http.StatusTooManyRequests, httpStatusCode)
+			header, ok := gwerrors.Header(err)
+			require.True(t, ok)
+			require.Equal(t, "application/json", header.Get("Content-Type"))
+
+			reason, domain, ok := gwerrors.Reason(err)
+			require.True(t, ok)
+			require.Equal(t, "QUOTA_EXCEEDED", reason)
+			require.Equal(t, "test.akuity.io", domain)
+			delay, ok := gwerrors.RetryDelay(err)
+			require.True(t, ok)
+			require.Equal(t, 3*time.Second, delay)
+
+			var quotaFailure *gwerrors.QuotaFailureError
+			require.True(t, errors.As(err, &quotaFailure))
+			require.Equal(t, "invitations", quotaFailure.Detail.GetViolations()[0].GetSubject())
+			require.Equal(t, codes.ResourceExhausted, status.Code(quotaFailure))
+
+			var badRequest *gwerrors.BadRequestError
+			require.False(t, errors.As(err, &badRequest))
+		})
+	}
+}
+
+func TestError_Status(t *testing.T) {
+	err := newError(t)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, "quota exceeded", st.Message())
+	require.Equal(t, "rpc error: code = ResourceExhausted desc = quota exceeded", err.Error())
+}
+
+func TestError_WithoutDetails(t *testing.T) {
+	err := status.Error(codes.Internal, "internal error")
+	_, ok := gwerrors.HTTPStatusCode(err)
+	require.False(t, ok)
+	_, _, ok = gwerrors.Reason(err)
+	require.False(t, ok)
+	_, ok = gwerrors.RetryDelay(gwerrors.New(status.New(codes.Internal, "internal error"), http.StatusInternalServerError, nil))
+	require.False(t, ok)
+}
+
+func TestNewTypeResolver(t *testing.T) {
+	testSets := map[string]gwerrors.TypeResolver{
+		"global types": nil,
+		"empty types":  &protoregistry.Types{},
+	}
+	for name, base := range testSets {
+		t.Run(name, func(t *testing.T) {
+			r := gwerrors.NewTypeResolver(base)
+			mt, err := r.FindMessageByURL("type.googleapis.com/google.rpc.BadRequest")
+			require.NoError(t, err)
+			require.Equal(t, "google.rpc.BadRequest", string(mt.Descriptor().FullName()))
+			_, err = r.FindMessageByName("google.rpc.Unknown")
+			require.ErrorIs(t, err, protoregistry.NotFound)
+		})
+	}
+}
@@ -10,48 +10,93 @@ import (
 )
 
 func setSkipTLSVerify(hc *http.Client, skip bool) {
+	ht, ok := getHTTPTransport(hc)
+	if !ok {
+		return
+	}
+	if ht.TLSClientConfig == nil {
+		ht.TLSClientConfig = &tls.Config{}
+	}
+	ht.TLSClientConfig.InsecureSkipVerify = skip
+}
+
+// getHTTPTransport unwraps the round tripper chain of the given client until
+// it reaches the underlying *http.Transport.
+func getHTTPTransport(hc *http.Client) (*http.Transport, bool) {
 	rt := roundtripper.GetRoundTripper(hc)
 	for {
 		ht, ok := rt.(*http.Transport)
 		if ok {
-			if ht.TLSClientConfig == nil {
-				ht.TLSClientConfig = &tls.Config{}
-			}
-			ht.TLSClientConfig.InsecureSkipVerify = skip
-			break
+			return ht, true
 		}
 
 		wrapped, ok := rt.(roundtripper.WrappedRoundTripper)
 		if !ok {
-			break
+			return nil, false
 		}
 		rt = wrapped.Unwrap()
 	}
 }
 
-// HTTPStatusToCode converts HTTP status code to gRPC error code
-// https://github.com/grpc/grpc/blob/9d2a1a3d1aba56d94c56d2e01cf2511d1a082445/doc/http-grpc-status-mapping.md
+// httpStatusToCode is the inverse of grpc-gateway's runtime.HTTPStatusFromCode.
+// Where several codes share a status, the most common one is picked.
+var httpStatusToCode = map[int]codes.Code{
+	http.StatusOK:                  codes.OK,
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusRequestTimeout:      codes.DeadlineExceeded,
+	http.StatusConflict:            codes.AlreadyExists,
+	http.StatusPreconditionFailed:  codes.FailedPrecondition,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	statusClientClosedRequest:      codes.Canceled,
+	http.StatusInternalServerError: codes.Internal,
+	http.StatusNotImplemented:      codes.Unimplemented,
+	http.StatusBadGateway:          codes.Unavailable,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+	http.StatusGatewayTimeout:      codes.DeadlineExceeded,
+}
+
+// codeToHTTPStatus matches grpc-gateway's runtime.HTTPStatusFromCode.
+var codeToHTTPStatus = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           statusClientClosedRequest,
+	codes.Unknown:            http.StatusInternalServerError,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Internal:           http.StatusInternalServerError,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DataLoss:           http.StatusInternalServerError,
+}
+
+// statusClientClosedRequest is the non-standard status used by grpc-gateway
+// for canceled requests.
+const statusClientClosedRequest = 499
+
+// HTTPStatusToCode converts HTTP status code to gRPC error code, consistently
+// with the status codes written by grpc-gateway.
 func HTTPStatusToCode(code int) codes.Code {
-	switch code {
-	case http.StatusOK:
-		return codes.OK
-	case http.StatusBadRequest:
-		return codes.Internal
-	case http.StatusUnauthorized:
-		return codes.Unauthenticated
-	case http.StatusForbidden:
-		return codes.PermissionDenied
-	case http.StatusNotFound:
-		return codes.Unimplemented
-	case http.StatusTooManyRequests:
-		return codes.Unavailable
-	case http.StatusBadGateway:
-		return codes.Unavailable
-	case http.StatusServiceUnavailable:
-		return codes.Unavailable
-	case http.StatusGatewayTimeout:
-		return codes.Unavailable
-	default:
-		return codes.Unknown
+	if c, ok := httpStatusToCode[code]; ok {
+		return c
+	}
+	return codes.Unknown
+}
+
+// CodeToHTTPStatus converts gRPC error code to HTTP status code, like
+// grpc-gateway does. It is the inverse of HTTPStatusToCode.
+func CodeToHTTPStatus(code codes.Code) int {
+	if status, ok := codeToHTTPStatus[code]; ok {
+		return status
 	}
+	return http.StatusInternalServerError
 }
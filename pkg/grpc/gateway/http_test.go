@@ -5,7 +5,9 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
 
 	"github.com/akuity/grpc-gateway-client/pkg/http/roundtripper"
 )
@@ -87,3 +89,27 @@ func Test_setSkipTLSVerify(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPStatusToCode(t *testing.T) {
+	for c := codes.OK; c <= codes.Unauthenticated; c++ {
+		httpStatus := CodeToHTTPStatus(c)
+		require.Equal(t, runtime.HTTPStatusFromCode(c), httpStatus, c.String())
+
+		// Codes that share a status with another code cannot round-trip.
+		switch c {
+		case codes.Unknown, codes.FailedPrecondition, codes.Aborted, codes.OutOfRange, codes.DataLoss:
+			continue
+		}
+		require.Equal(t, c, HTTPStatusToCode(httpStatus), c.String())
+	}
+
+	testSets := map[int]codes.Code{
+		http.StatusRequestTimeout:     codes.DeadlineExceeded,
+		http.StatusPreconditionFailed: codes.FailedPrecondition,
+		http.StatusBadGateway:         codes.Unavailable,
+		http.StatusTeapot:             codes.Unknown,
+	}
+	for httpStatus, expected := range testSets {
+		require.Equal(t, expected, HTTPStatusToCode(httpStatus), http.StatusText(httpStatus))
+	}
+}
@@ -0,0 +1,33 @@
+package gateway
+
+import (
+	"io"
+
+	"github.com/go-resty/resty/v2"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+)
+
+const defaultHTTPBodyContentType = "application/octet-stream"
+
+// SetHTTPBody sets the data of the given google.api.HttpBody as the raw request
+// body with its content type, as grpc-gateway expects for HttpBody requests.
+func SetHTTPBody(req *resty.Request, body *httpbody.HttpBody) *resty.Request {
+	return req.
+		SetHeader("Content-Type", getHTTPBodyContentType(body.GetContentType())).
+		SetBody(body.GetData())
+}
+
+// SetHTTPBodyReader is like SetHTTPBody, but streams the request body from the
+// given reader instead of buffering it in memory.
+func SetHTTPBodyReader(req *resty.Request, contentType string, body io.Reader) *resty.Request {
+	return req.
+		SetHeader("Content-Type", getHTTPBodyContentType(contentType)).
+		SetBody(body)
+}
+
+func getHTTPBodyContentType(contentType string) string {
+	if contentType == "" {
+		return defaultHTTPBodyContentType
+	}
+	return contentType
+}
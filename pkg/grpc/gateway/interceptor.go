@@ -0,0 +1,285 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryInvoker sends a unary request and decodes the response into reply.
+type UnaryInvoker func(ctx context.Context, method string, req, reply any) error
+
+// UnaryClientInterceptor intercepts the unary calls of the generated clients,
+// like grpc.UnaryClientInterceptor. method is the full gRPC method name, e.g.
+// "/package.Service/Method". req is nil for requests streamed from a reader.
+type UnaryClientInterceptor func(ctx context.Context, method string, req, reply any, c Client, invoker UnaryInvoker) error
+
+// ClientStream is a streaming call seen by the stream interceptors.
+type ClientStream interface {
+	// Context returns the context of the stream.
+	Context() context.Context
+	// RecvMsg receives the next response message. It returns io.EOF when the
+	// stream ends successfully.
+	RecvMsg() (any, error)
+	// SendMsg sends a request message. Only bidirectional streams support it.
+	SendMsg(m any) error
+	// CloseSend closes the sending direction of the stream.
+	CloseSend() error
+}
+
+// Streamer starts a streaming call.
+type Streamer func(ctx context.Context, method string, req any) (ClientStream, error)
+
+// StreamClientInterceptor intercepts the streaming calls of the generated
+// clients, like grpc.StreamClientInterceptor. req is the request of server
+// streams, and nil for bidirectional streams.
+type StreamClientInterceptor func(ctx context.Context, method string, req any, c Client, streamer Streamer) (ClientStream, error)
+
+func getUnaryInterceptors(c Client) []UnaryClientInterceptor {
+	if cc, ok := c.(*client); ok {
+		return cc.unaryInterceptors
+	}
+	return nil
+}
+
+func getStreamInterceptors(c Client) []StreamClientInterceptor {
+	if cc, ok := c.(*client); ok {
+		return cc.streamInterceptors
+	}
+	return nil
+}
+
+// Invoke sends the unary request of the given method through the interceptors
+// of the client. The generated clients call it for every unary method.
+func Invoke[T any](ctx context.Context, c Client, method string, req any, gwReq *resty.Request) (*T, error) {
+	ctx = withMethod(ctx, method)
+
+	// resty rewrites the URL with the path and query params on every send, and
+	// interceptors may invoke the request more than once.
+	url := gwReq.URL
+	invoker := func(ctx context.Context, method string, req, reply any) error {
+		gwReq.URL = url
+		start := time.Now()
+		res, err := doUnaryRequest(ctx, c, gwReq, reply)
+		if l, ok := getLogConfig(c); ok {
+			l.logUnaryCall(ctx, c, method, gwReq.Method+" "+url, req, reply, res, err, time.Since(start))
+		}
+		return err
+	}
+	interceptors := getUnaryInterceptors(c)
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoker
+		invoker = func(ctx context.Context, method string, req, reply any) error {
+			return interceptor(ctx, method, req, reply, c, next)
+		}
+	}
+
+	var reply T
+	if err := invoker(ctx, method, req, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+func chainStreamer(c Client, streamer Streamer) Streamer {
+	interceptors := getStreamInterceptors(c)
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], streamer
+		streamer = func(ctx context.Context, method string, req any) (ClientStream, error) {
+			return interceptor(ctx, method, req, c, next)
+		}
+	}
+	return streamer
+}
+
+// withStreamLogging logs the streams started by the streamer, if the client
+// has a logger.
+func withStreamLogging(c Client, route string, streamer Streamer) Streamer {
+	l, ok := getLogConfig(c)
+	if !ok {
+		return streamer
+	}
+	return func(ctx context.Context, method string, req any) (ClientStream, error) {
+		start := time.Now()
+		cs, err := streamer(ctx, method, req)
+		if err != nil {
+			var attrs []slog.Attr
+			if l.payloads && req != nil {
+				attrs = append(attrs, l.payloadAttr(c, "grpc.request", req))
+			}
+			l.logCall(ctx, method, route, err, time.Since(start), attrs...)
+			return nil, err
+		}
+		return l.observeStream(ctx, c, method, route, req, start, cs), nil
+	}
+}
+
+// InvokeStream starts the server stream of the given method through the
+// interceptors of the client. The generated clients call it for every server
+// streaming method.
+func InvokeStream[T any](ctx context.Context, c Client, method string, req any, gwReq *resty.Request) (<-chan *T, <-chan error, error) {
+	ctx = withMethod(ctx, method)
+	_, logged := getLogConfig(c)
+	if len(getStreamInterceptors(c)) == 0 && !logged {
+		return DoStreamingRequest[T](ctx, c, gwReq)
+	}
+
+	url := gwReq.URL
+	streamer := chainStreamer(c, withStreamLogging(c, gwReq.Method+" "+url, func(ctx context.Context, _ string, _ any) (ClientStream, error) {
+		gwReq.URL = url
+		resCh, errCh, err := DoStreamingRequest[T](ctx, c, gwReq)
+		if err != nil {
+			return nil, err
+		}
+		return &serverStream[T]{
+			ctx:   ctx,
+			resCh: resCh,
+			errCh: errCh,
+		}, nil
+	}))
+	cs, err := streamer(ctx, method, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stream := newResponseStream[T](ctx)
+	go func() {
+		for {
+			msg, err := cs.RecvMsg()
+			if errors.Is(err, io.EOF) {
+				stream.close(nil)
+				return
+			}
+			if err != nil {
+				stream.close(err)
+				return
+			}
+			res, ok := msg.(*T)
+			if !ok {
+				stream.close(fmt.Errorf("unexpected stream message type %T", msg))
+				return
+			}
+			if !stream.send(res) {
+				return
+			}
+		}
+	}()
+	return stream.resCh, stream.errCh, nil
+}
+
+// InvokeBidiStream starts the bidirectional stream of the given method through
+// the interceptors of the client. The generated clients call it for every
+// bidirectional streaming method.
+func InvokeBidiStream[Req, Res any](ctx context.Context, c Client, method string, gwReq *resty.Request) (BidiStream[Req, Res], error) {
+	ctx = withMethod(ctx, method)
+	_, logged := getLogConfig(c)
+	if len(getStreamInterceptors(c)) == 0 && !logged {
+		return DoBidiStreamingRequest[Req, Res](ctx, c, gwReq)
+	}
+
+	streamer := chainStreamer(c, withStreamLogging(c, gwReq.Method+" "+gwReq.URL, func(ctx context.Context, _ string, _ any) (ClientStream, error) {
+		s, err := DoBidiStreamingRequest[Req, Res](ctx, c, gwReq)
+		if err != nil {
+			return nil, err
+		}
+		return &bidiClientStream[Req, Res]{
+			ctx: ctx,
+			s:   s,
+		}, nil
+	}))
+	cs, err := streamer(ctx, method, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &interceptedBidiStream[Req, Res]{
+		cs: cs,
+	}, nil
+}
+
+// serverStream is the ClientStream of the channels of a server stream.
+type serverStream[T any] struct {
+	ctx   context.Context
+	resCh <-chan *T
+	errCh <-chan error
+}
+
+func (s *serverStream[T]) Context() context.Context {
+	return s.ctx
+}
+
+func (s *serverStream[T]) RecvMsg() (any, error) {
+	if res, ok := <-s.resCh; ok {
+		return res, nil
+	}
+	if err := <-s.errCh; err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+func (s *serverStream[T]) SendMsg(any) error {
+	return status.Error(codes.Internal, "cannot send messages on a server stream")
+}
+
+func (s *serverStream[T]) CloseSend() error {
+	return nil
+}
+
+// bidiClientStream is the ClientStream of a bidirectional stream.
+type bidiClientStream[Req, Res any] struct {
+	ctx context.Context
+	s   BidiStream[Req, Res]
+}
+
+func (s *bidiClientStream[Req, Res]) Context() context.Context {
+	return s.ctx
+}
+
+func (s *bidiClientStream[Req, Res]) RecvMsg() (any, error) {
+	return s.s.Recv()
+}
+
+func (s *bidiClientStream[Req, Res]) SendMsg(m any) error {
+	req, ok := m.(*Req)
+	if !ok {
+		return fmt.Errorf("unexpected stream message type %T", m)
+	}
+	return s.s.Send(req)
+}
+
+func (s *bidiClientStream[Req, Res]) CloseSend() error {
+	return s.s.CloseSend()
+}
+
+// interceptedBidiStream is the BidiStream of a ClientStream returned by the
+// interceptors.
+type interceptedBidiStream[Req, Res any] struct {
+	cs ClientStream
+}
+
+func (s *interceptedBidiStream[Req, Res]) Send(req *Req) error {
+	return s.cs.SendMsg(req)
+}
+
+func (s *interceptedBidiStream[Req, Res]) Recv() (*Res, error) {
+	msg, err := s.cs.RecvMsg()
+	if err != nil {
+		return nil, err
+	}
+	res, ok := msg.(*Res)
+	if !ok {
+		return nil, fmt.Errorf("unexpected stream message type %T", msg)
+	}
+	return res, nil
+}
+
+func (s *interceptedBidiStream[Req, Res]) CloseSend() error {
+	return s.cs.CloseSend()
+}
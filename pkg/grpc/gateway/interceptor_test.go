@@ -0,0 +1,119 @@
+package gateway_test
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/akuity/grpc-gateway-client/internal/test/gen/testv1"
+	"github.com/akuity/grpc-gateway-client/pkg/grpc/gateway"
+)
+
+// countingStream counts the messages received on the stream.
+type countingStream struct {
+	gateway.ClientStream
+
+	received *int
+}
+
+func (s *countingStream) RecvMsg() (any, error) {
+	msg, err := s.ClientStream.RecvMsg()
+	if err == nil {
+		*s.received++
+	}
+	return msg, err
+}
+
+func (s *RequestTestSuite) TestUnaryInterceptor() {
+	var calls []string
+	newInterceptor := func(name string) gateway.UnaryClientInterceptor {
+		return func(ctx context.Context, method string, req, reply any, c gateway.Client, invoker gateway.UnaryInvoker) error {
+			calls = append(calls, name+" "+method)
+			s.Require().Equal("test@test.com", req.(*testv1.SendInvitationRequest).GetEmail())
+			if err := invoker(ctx, method, req, reply); err != nil {
+				return err
+			}
+			s.Require().NotEmpty(reply.(*testv1.SendInvitationResponse).GetId())
+			return nil
+		}
+	}
+	client := testv1.NewTestServiceGatewayClient(gateway.NewClient(s.gwSrv.URL,
+		gateway.WithUnaryInterceptor(newInterceptor("first")),
+		gateway.WithUnaryInterceptor(newInterceptor("second")),
+	))
+
+	res, err := client.SendInvitation(context.TODO(), &testv1.SendInvitationRequest{
+		Email: "test@test.com",
+	})
+	s.Require().NoError(err)
+	s.Require().NotEmpty(res.GetId())
+	s.Require().Equal([]string{
+		"first /io.akuity.test.v1.TestService/SendInvitation",
+		"second /io.akuity.test.v1.TestService/SendInvitation",
+	}, calls)
+}
+
+func (s *RequestTestSuite) TestUnaryInterceptor_Error() {
+	client := testv1.NewTestServiceGatewayClient(gateway.NewClient(s.gwSrv.URL,
+		gateway.WithUnaryInterceptor(func(context.Context, string, any, any, gateway.Client, gateway.UnaryInvoker) error {
+			return status.Error(codes.PermissionDenied, "denied")
+		}),
+	))
+
+	_, err := client.SendInvitation(context.TODO(), &testv1.SendInvitationRequest{
+		Email: "test@test.com",
+	})
+	s.Require().Equal(codes.PermissionDenied, status.Code(err))
+}
+
+func (s *RequestTestSuite) TestStreamInterceptor() {
+	var methods []string
+	var received int
+	client := testv1.NewTestServiceGatewayClient(gateway.NewClient(s.gwSrv.URL,
+		gateway.WithStreamInterceptor(func(ctx context.Context, method string, req any, c gateway.Client, streamer gateway.Streamer) (gateway.ClientStream, error) {
+			methods = append(methods, method)
+			cs, err := streamer(ctx, method, req)
+			if err != nil {
+				return nil, err
+			}
+			return &countingStream{
+				ClientStream: cs,
+				received:     &received,
+			}, nil
+		}),
+	))
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+	resCh, errCh, err := client.TrackInvitation(ctx, &testv1.TrackInvitationRequest{
+		Id: "some-id",
+	})
+	s.Require().NoError(err)
+	var messages int
+	for range resCh {
+		messages++
+	}
+	s.Require().NoError(<-errCh)
+	s.Require().Equal(2, messages)
+
+	stream, err := client.DiscussInvitation(ctx)
+	s.Require().NoError(err)
+	s.Require().NoError(stream.Send(&testv1.DiscussInvitationRequest{
+		Message: "hello",
+	}))
+	res, err := stream.Recv()
+	s.Require().NoError(err)
+	s.Require().Equal("hello", res.GetMessage())
+	s.Require().NoError(stream.CloseSend())
+	_, err = stream.Recv()
+	s.Require().ErrorIs(err, io.EOF)
+
+	s.Require().Equal([]string{
+		"/io.akuity.test.v1.TestService/TrackInvitation",
+		"/io.akuity.test.v1.TestService/DiscussInvitation",
+	}, methods)
+	s.Require().Equal(3, received)
+}
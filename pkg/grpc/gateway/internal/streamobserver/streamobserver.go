@@ -0,0 +1,106 @@
+// Package streamobserver notifies the stream interceptors of the gateway
+// client of the messages of a stream and of its end.
+package streamobserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"google.golang.org/grpc/status"
+)
+
+// ClientStream has the methods of gateway.ClientStream, which cannot be
+// imported here.
+type ClientStream interface {
+	Context() context.Context
+	RecvMsg() (any, error)
+	SendMsg(m any) error
+	CloseSend() error
+}
+
+// Observer is notified of the events of a stream. Its functions are never
+// called concurrently, and none is called after End.
+type Observer struct {
+	// Sent is called after a message was sent.
+	Sent func(m any)
+	// Received is called after a message was received.
+	Received func(m any)
+	// End is called once the stream ended, with a nil error if it ended
+	// successfully.
+	End func(err error)
+}
+
+// New wraps the stream to notify the observer. The stream ends when sending or
+// receiving fails, when RecvMsg returns io.EOF, or when ctx is done, so that
+// abandoned streams end too.
+func New(ctx context.Context, cs ClientStream, o Observer) ClientStream {
+	s := &observedStream{
+		ClientStream: cs,
+		observer:     o,
+		done:         make(chan struct{}),
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			s.end(status.FromContextError(ctx.Err()).Err())
+		case <-s.done:
+		}
+	}()
+	return s
+}
+
+type observedStream struct {
+	ClientStream
+
+	observer Observer
+
+	mu    sync.Mutex
+	ended bool
+	done  chan struct{}
+}
+
+func (s *observedStream) RecvMsg() (any, error) {
+	msg, err := s.ClientStream.RecvMsg()
+	if errors.Is(err, io.EOF) {
+		s.end(nil)
+		return msg, err
+	}
+	if err != nil {
+		s.end(err)
+		return msg, err
+	}
+	s.notify(s.observer.Received, msg)
+	return msg, nil
+}
+
+func (s *observedStream) SendMsg(m any) error {
+	if err := s.ClientStream.SendMsg(m); err != nil {
+		s.end(err)
+		return err
+	}
+	s.notify(s.observer.Sent, m)
+	return nil
+}
+
+func (s *observedStream) notify(f func(m any), m any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.ended && f != nil {
+		f(m)
+	}
+}
+
+func (s *observedStream) end(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ended {
+		return
+	}
+	s.ended = true
+	if s.observer.End != nil {
+		s.observer.End(err)
+	}
+	close(s.done)
+}
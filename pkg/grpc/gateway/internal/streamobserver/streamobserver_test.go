@@ -0,0 +1,101 @@
+package streamobserver
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeStream struct {
+	ctx      context.Context
+	messages []any
+	err      error
+}
+
+func (s *fakeStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeStream) RecvMsg() (any, error) {
+	if len(s.messages) == 0 {
+		return nil, s.err
+	}
+	msg := s.messages[0]
+	s.messages = s.messages[1:]
+	return msg, nil
+}
+
+func (s *fakeStream) SendMsg(_ any) error {
+	return s.err
+}
+
+func (s *fakeStream) CloseSend() error {
+	return nil
+}
+
+func TestNew(t *testing.T) {
+	testSets := map[string]struct {
+		err          error
+		expectedCode codes.Code
+	}{
+		"end of stream": {
+			err:          io.EOF,
+			expectedCode: codes.OK,
+		},
+		"error": {
+			err:          status.Error(codes.Internal, "broken"),
+			expectedCode: codes.Internal,
+		},
+	}
+	for name, ts := range testSets {
+		t.Run(name, func(t *testing.T) {
+			var received []any
+			var ends []error
+			cs := New(context.Background(), &fakeStream{
+				ctx:      context.Background(),
+				messages: []any{"1", "2"},
+				err:      ts.err,
+			}, Observer{
+				Received: func(m any) {
+					received = append(received, m)
+				},
+				End: func(err error) {
+					ends = append(ends, err)
+				},
+			})
+			for {
+				if _, err := cs.RecvMsg(); err != nil {
+					break
+				}
+			}
+			_ = cs.SendMsg("3")
+
+			require.Equal(t, []any{"1", "2"}, received)
+			require.Len(t, ends, 1)
+			require.Equal(t, ts.expectedCode, status.Code(ends[0]))
+		})
+	}
+}
+
+func TestNew_Abandoned(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ended := make(chan error, 1)
+	New(ctx, &fakeStream{ctx: ctx}, Observer{
+		End: func(err error) {
+			ended <- err
+		},
+	})
+	cancel()
+
+	select {
+	case err := <-ended:
+		require.Equal(t, codes.Canceled, status.Code(err))
+	case <-time.After(time.Second):
+		require.Fail(t, "stream did not end")
+	}
+}
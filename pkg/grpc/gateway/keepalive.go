@@ -0,0 +1,109 @@
+package gateway
+
+import (
+	"mime"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var eventStreamKeepAlive = []byte(": keepalive\n\n")
+
+// KeepAliveEventStreams returns a handler that writes a keepalive comment to
+// the server-sent event streams of h whenever the interval elapses, so that
+// idle streams are not cut off by proxies.
+func KeepAliveEventStreams(h http.Handler, interval time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		kw := &keepAliveWriter{
+			ResponseWriter: w,
+			interval:       interval,
+			done:           make(chan struct{}),
+		}
+		defer kw.stop()
+		h.ServeHTTP(kw, r)
+	})
+}
+
+var (
+	_ http.ResponseWriter = &keepAliveWriter{}
+	_ http.Flusher        = &keepAliveWriter{}
+)
+
+type keepAliveWriter struct {
+	http.ResponseWriter
+	interval time.Duration
+
+	mu      sync.Mutex
+	started bool
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+func (w *keepAliveWriter) WriteHeader(code int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.ResponseWriter.WriteHeader(code)
+	w.start(code)
+}
+
+func (w *keepAliveWriter) Write(data []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	n, err := w.ResponseWriter.Write(data)
+	w.start(http.StatusOK)
+	return n, err
+}
+
+func (w *keepAliveWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap returns the underlying writer for http.ResponseController.
+func (w *keepAliveWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// start starts sending keepalives once the response turns out to be a
+// successful event stream. It must be called with the lock held.
+func (w *keepAliveWriter) start(code int) {
+	if w.started {
+		return
+	}
+	w.started = true
+	mediaType, _, _ := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	if code != http.StatusOK || mediaType != eventStreamContentType {
+		return
+	}
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-w.done:
+				return
+			case <-ticker.C:
+				w.mu.Lock()
+				_, err := w.ResponseWriter.Write(eventStreamKeepAlive)
+				if f, ok := w.ResponseWriter.(http.Flusher); ok && err == nil {
+					f.Flush()
+				}
+				w.mu.Unlock()
+				if err != nil {
+					return
+				}
+			}
+		}
+	}()
+}
+
+func (w *keepAliveWriter) stop() {
+	close(w.done)
+	w.wg.Wait()
+}
@@ -0,0 +1,51 @@
+package gateway_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/akuity/grpc-gateway-client/pkg/grpc/gateway"
+)
+
+func TestKeepAliveEventStreams(t *testing.T) {
+	testSets := map[string]struct {
+		contentType       string
+		keepAliveExpected bool
+	}{
+		"event stream": {
+			contentType:       "text/event-stream",
+			keepAliveExpected: true,
+		},
+		"json": {
+			contentType: "application/json",
+		},
+	}
+	for name, ts := range testSets {
+		t.Run(name, func(t *testing.T) {
+			h := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", ts.contentType)
+				_, _ = w.Write([]byte("data: {}\n\n"))
+				w.(http.Flusher).Flush()
+				time.Sleep(50 * time.Millisecond)
+			})
+			srv := httptest.NewServer(gateway.KeepAliveEventStreams(h, 10*time.Millisecond))
+			defer srv.Close()
+
+			res, err := http.Get(srv.URL)
+			require.NoError(t, err)
+			defer func() { _ = res.Body.Close() }()
+			data, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			if ts.keepAliveExpected {
+				require.Contains(t, string(data), ": keepalive\n\n")
+			} else {
+				require.Equal(t, "data: {}\n\n", string(data))
+			}
+		})
+	}
+}
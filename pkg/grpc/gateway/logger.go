@@ -0,0 +1,240 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	gwerrors "github.com/akuity/grpc-gateway-client/pkg/grpc/gateway/errors"
+	"github.com/akuity/grpc-gateway-client/pkg/grpc/gateway/internal/streamobserver"
+)
+
+const redactedValue = "REDACTED"
+
+// wellKnownCredentialHeaders are masked whenever headers are logged, also
+// when they are sent as gRPC metadata.
+var wellKnownCredentialHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+}
+
+type logConfig struct {
+	logger         *slog.Logger
+	payloads       bool
+	redactedFields map[protoreflect.FullName]bool
+}
+
+// getLogConfig returns the logging configuration of the given client, if it
+// has a logger.
+func getLogConfig(c Client) (*logConfig, bool) {
+	if cc, ok := c.(*client); ok && cc.log.logger != nil {
+		return &cc.log, true
+	}
+	return nil, false
+}
+
+// logCall logs a finished call, at the error level if it failed.
+func (l *logConfig) logCall(ctx context.Context, method, route string, err error, duration time.Duration, attrs ...slog.Attr) {
+	code := status.Code(err)
+	level := slog.LevelInfo
+	if code != codes.OK {
+		level = slog.LevelError
+	}
+	attrs = append([]slog.Attr{
+		slog.String("grpc.method", method),
+		slog.String("http.route", route),
+		slog.String("grpc.code", code.String()),
+		slog.Duration("duration", duration),
+	}, attrs...)
+	if httpStatus, ok := getErrorHTTPStatusCode(err); ok {
+		attrs = append(attrs, slog.Int("http.status", httpStatus))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	l.logger.LogAttrs(ctx, level, "finished call", attrs...)
+}
+
+// logUnaryCall logs a unary call along with its response, which is nil if no
+// response arrived.
+func (l *logConfig) logUnaryCall(ctx context.Context, c Client, method, route string, req, reply any, res *resty.Response, err error, duration time.Duration) {
+	var attrs []slog.Attr
+	if res != nil && err == nil {
+		attrs = append(attrs, slog.Int("http.status", res.StatusCode()))
+	}
+	if l.payloads {
+		attrs = append(attrs, l.payloadAttr(c, "grpc.request", req))
+		if res != nil {
+			if res.Request != nil && res.Request.RawRequest != nil {
+				attrs = append(attrs, slog.Any("http.request.header", maskHeader(c, res.Request.RawRequest.Header)))
+			}
+			attrs = append(attrs, slog.Any("http.response.header", maskHeader(c, res.Header())))
+		}
+		if err == nil {
+			attrs = append(attrs, l.payloadAttr(c, "grpc.response", reply))
+		}
+	}
+	l.logCall(ctx, method, route, err, duration, attrs...)
+}
+
+// payloadAttr returns the message with its sensitive fields redacted. Raw
+// HTTP bodies are only described.
+func (l *logConfig) payloadAttr(c Client, key string, m any) slog.Attr {
+	switch msg := m.(type) {
+	case *httpbody.HttpBody:
+		return slog.Group(key,
+			slog.String("content_type", msg.GetContentType()),
+			slog.Int("size", len(msg.GetData())),
+		)
+	case proto.Message:
+		msg = proto.Clone(msg)
+		redactMessage(msg.ProtoReflect(), l.redactedFields)
+		data, err := c.Marshal(msg)
+		if err != nil {
+			return slog.String(key, "marshal: "+err.Error())
+		}
+		return slog.String(key, string(data))
+	default:
+		return slog.Attr{}
+	}
+}
+
+// redactMessage clears the fields marked with the debug_redact option or
+// listed in fields. Redacted strings are replaced with a placeholder, so that
+// the log shows that they were set.
+func redactMessage(m protoreflect.Message, fields map[protoreflect.FullName]bool) {
+	var redacted []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if isRedactedField(fd, fields) {
+			redacted = append(redacted, fd)
+			return true
+		}
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+					redactMessage(mv.Message(), fields)
+					return true
+				})
+			}
+		case fd.IsList():
+			if fd.Message() != nil {
+				for i := 0; i < v.List().Len(); i++ {
+					redactMessage(v.List().Get(i).Message(), fields)
+				}
+			}
+		case fd.Message() != nil:
+			redactMessage(v.Message(), fields)
+		}
+		return true
+	})
+	for _, fd := range redacted {
+		if fd.Kind() == protoreflect.StringKind && fd.Cardinality() != protoreflect.Repeated {
+			m.Set(fd, protoreflect.ValueOfString(redactedValue))
+			continue
+		}
+		m.Clear(fd)
+	}
+}
+
+func isRedactedField(fd protoreflect.FieldDescriptor, fields map[protoreflect.FullName]bool) bool {
+	if fields[fd.FullName()] {
+		return true
+	}
+	opts, ok := fd.Options().(*descriptorpb.FieldOptions)
+	return ok && opts.GetDebugRedact()
+}
+
+// maskHeader returns a copy of the header with the values of the credential
+// headers masked.
+func maskHeader(c Client, header http.Header) http.Header {
+	masked := header.Clone()
+	for name, values := range masked {
+		if !isCredentialHeader(c, name) {
+			continue
+		}
+		for i := range values {
+			values[i] = redactedValue
+		}
+	}
+	return masked
+}
+
+// isCredentialHeader reports whether the header is a well-known credential
+// header, or was sent for the per-RPC credentials of the client.
+func isCredentialHeader(c Client, name string) bool {
+	if wellKnownCredentialHeaders[strings.TrimPrefix(name, runtime.MetadataHeaderPrefix)] {
+		return true
+	}
+	cc, ok := c.(*client)
+	if !ok {
+		return false
+	}
+	_, ok = cc.credentialHeaders.Load(http.CanonicalHeaderKey(name))
+	return ok
+}
+
+// getErrorHTTPStatusCode returns the HTTP status of the response that the
+// error was read from, if any.
+func getErrorHTTPStatusCode(err error) (int, bool) {
+	if httpStatus, ok := gwerrors.HTTPStatusCode(err); ok && httpStatus != 0 {
+		return httpStatus, true
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode, true
+	}
+	return 0, false
+}
+
+// observeStream logs the stream when it ends, and its messages if payloads
+// are logged.
+func (l *logConfig) observeStream(ctx context.Context, c Client, method, route string, req any, start time.Time, cs ClientStream) ClientStream {
+	var sent, received int
+	if req != nil {
+		sent = 1
+	}
+	logMessage := func(msg, key string, m any) {
+		if !l.payloads {
+			return
+		}
+		l.logger.LogAttrs(ctx, slog.LevelDebug, msg,
+			slog.String("grpc.method", method),
+			l.payloadAttr(c, key, m),
+		)
+	}
+	return streamobserver.New(ctx, cs, streamobserver.Observer{
+		Sent: func(m any) {
+			sent++
+			logMessage("sent message", "grpc.request", m)
+		},
+		Received: func(m any) {
+			received++
+			logMessage("received message", "grpc.response", m)
+		},
+		End: func(err error) {
+			attrs := []slog.Attr{
+				slog.Int("grpc.sent_messages", sent),
+				slog.Int("grpc.received_messages", received),
+			}
+			if l.payloads && req != nil {
+				attrs = append(attrs, l.payloadAttr(c, "grpc.request", req))
+			}
+			l.logCall(ctx, method, route, err, time.Since(start), attrs...)
+		},
+	})
+}
@@ -0,0 +1,165 @@
+package gateway_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/akuity/grpc-gateway-client/internal/test/gen/testv1"
+	"github.com/akuity/grpc-gateway-client/pkg/grpc/gateway"
+)
+
+// decodeLogs returns the records written by a JSON handler.
+func (s *RequestTestSuite) decodeLogs(buf *bytes.Buffer) []map[string]any {
+	var records []map[string]any
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var record map[string]any
+		s.Require().NoError(dec.Decode(&record))
+		delete(record, "time")
+		delete(record, "duration")
+		records = append(records, record)
+	}
+	return records
+}
+
+func (s *RequestTestSuite) TestLogger() {
+	var buf bytes.Buffer
+	client := testv1.NewTestServiceGatewayClient(gateway.NewClient(s.gwSrv.URL,
+		gateway.WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))),
+	))
+
+	_, err := client.SendInvitation(context.TODO(), &testv1.SendInvitationRequest{
+		Email: "test@test.com",
+	})
+	s.Require().NoError(err)
+	_, err = client.SendInvitation(context.TODO(), &testv1.SendInvitationRequest{})
+	s.Require().Error(err)
+
+	s.Require().Equal([]map[string]any{
+		{
+			"level":       "INFO",
+			"msg":         "finished call",
+			"grpc.method": "/io.akuity.test.v1.TestService/SendInvitation",
+			"http.route":  "POST /invitation",
+			"grpc.code":   "OK",
+			"http.status": float64(200),
+		},
+		{
+			"level":       "ERROR",
+			"msg":         "finished call",
+			"grpc.method": "/io.akuity.test.v1.TestService/SendInvitation",
+			"http.route":  "POST /invitation",
+			"grpc.code":   "InvalidArgument",
+			"http.status": float64(400),
+			"error":       "rpc error: code = InvalidArgument desc = email is required",
+		},
+	}, s.decodeLogs(&buf))
+}
+
+func (s *RequestTestSuite) TestLogger_Payloads() {
+	var buf bytes.Buffer
+	client := testv1.NewTestServiceGatewayClient(gateway.NewClient(s.gwSrv.URL,
+		gateway.WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))),
+		gateway.WithPayloadLogging(),
+		gateway.WithRedactedFields("io.akuity.test.v1.Invitation.labels"),
+	))
+
+	ctx := metadata.AppendToOutgoingContext(context.TODO(), "authorization", "Bearer token")
+	res, err := client.SendInvitation(ctx, &testv1.SendInvitationRequest{
+		Email: "test@test.com",
+	})
+	s.Require().NoError(err)
+	_, err = client.ListInvitations(context.TODO(), &testv1.ListInvitationsRequest{
+		Query: &testv1.ListInvitationsQuery{
+			Labels: map[string]string{"token": "secret"},
+		},
+	})
+	s.Require().NoError(err)
+
+	records := s.decodeLogs(&buf)
+	s.Require().Len(records, 2)
+	// The email is marked with debug_redact.
+	s.Require().JSONEq(`{"email":"REDACTED"}`, records[0]["grpc.request"].(string))
+	s.Require().JSONEq(`{"id":"`+res.GetId()+`"}`, records[0]["grpc.response"].(string))
+	header := records[0]["http.request.header"].(map[string]any)
+	s.Require().Equal([]any{"REDACTED"}, header["Grpc-Metadata-Authorization"])
+	s.Require().NotContains(buf.String(), "Bearer token")
+
+	// Redacting the labels of the invitations leaves the ones of the query.
+	s.Require().JSONEq(`{"query":{"labels":{"token":"secret"}}}`, records[1]["grpc.request"].(string))
+	s.Require().JSONEq(`{"invitations":[{"id":"test-invitation"}]}`, records[1]["grpc.response"].(string))
+}
+
+func (s *RequestTestSuite) TestLogger_PerRPCCredentials() {
+	var buf bytes.Buffer
+	client := testv1.NewTestServiceGatewayClient(gateway.NewClient(s.gwSrv.URL,
+		gateway.WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))),
+		gateway.WithPayloadLogging(),
+		gateway.WithPerRPCCredentials(&fakePerRPCCredentials{
+			md: map[string]string{"x-api-key": "secret-key"},
+		}),
+	))
+
+	ctx := metadata.AppendToOutgoingContext(context.TODO(), "x-tenant", "akuity")
+	_, err := client.SendInvitation(ctx, &testv1.SendInvitationRequest{
+		Email: "test@test.com",
+	})
+	s.Require().NoError(err)
+
+	records := s.decodeLogs(&buf)
+	s.Require().Len(records, 1)
+	header := records[0]["http.request.header"].(map[string]any)
+	s.Require().Equal([]any{"REDACTED"}, header["Grpc-Metadata-X-Api-Key"])
+	s.Require().Equal([]any{"akuity"}, header["Grpc-Metadata-X-Tenant"])
+	s.Require().NotContains(buf.String(), "secret-key")
+}
+
+func (s *RequestTestSuite) TestLogger_Stream() {
+	var buf bytes.Buffer
+	client := testv1.NewTestServiceGatewayClient(gateway.NewClient(s.gwSrv.URL,
+		gateway.WithLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+			Level: slog.LevelDebug,
+		}))),
+		gateway.WithPayloadLogging(),
+	))
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+	resCh, errCh, err := client.TrackInvitation(ctx, &testv1.TrackInvitationRequest{
+		Id: "some-id",
+	})
+	s.Require().NoError(err)
+	for range resCh {
+	}
+	s.Require().NoError(<-errCh)
+
+	s.Require().Equal([]map[string]any{
+		{
+			"level":         "DEBUG",
+			"msg":           "received message",
+			"grpc.method":   "/io.akuity.test.v1.TestService/TrackInvitation",
+			"grpc.response": `{"type":"EVENT_TYPE_SEEN"}`,
+		},
+		{
+			"level":         "DEBUG",
+			"msg":           "received message",
+			"grpc.method":   "/io.akuity.test.v1.TestService/TrackInvitation",
+			"grpc.response": `{"type":"EVENT_TYPE_ACCEPTED"}`,
+		},
+		{
+			"level":                  "INFO",
+			"msg":                    "finished call",
+			"grpc.method":            "/io.akuity.test.v1.TestService/TrackInvitation",
+			"http.route":             "GET /invitation/{id}",
+			"grpc.code":              "OK",
+			"grpc.sent_messages":     float64(1),
+			"grpc.received_messages": float64(2),
+			"grpc.request":           `{"id":"some-id"}`,
+		},
+	}, s.decodeLogs(&buf))
+}
@@ -3,19 +3,64 @@ package gateway
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
 
+	"github.com/alevinval/sse/pkg/decoder"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
-func NewEventStreamMarshaller(marshaller *runtime.JSONPb) runtime.Marshaler {
-	return &eventStreamMarshaller{
+// eventStreamErrorEvent is the name of the events that carry a stream error.
+const eventStreamErrorEvent = "error"
+
+type EventStreamOption func(*eventStreamMarshaller)
+
+// WithEventIDField sets the id of every event to the value of the given field
+// of the streamed message. Events of messages without the field have no id.
+func WithEventIDField(name protoreflect.Name) EventStreamOption {
+	return func(e *eventStreamMarshaller) {
+		e.idField = name
+	}
+}
+
+// WithEventIDSequence sets the id of every event to the next value of a
+// sequence shared by all the streams of the marshaller, so the ids are unique
+// and increasing.
+func WithEventIDSequence() EventStreamOption {
+	return func(e *eventStreamMarshaller) {
+		e.idSequence = &atomic.Uint64{}
+	}
+}
+
+// WithEventRetry sends the reconnection delay to the clients with every event.
+func WithEventRetry(retry time.Duration) EventStreamOption {
+	return func(e *eventStreamMarshaller) {
+		e.retry = retry
+	}
+}
+
+func NewEventStreamMarshaller(marshaller *runtime.JSONPb, opts ...EventStreamOption) runtime.Marshaler {
+	e := &eventStreamMarshaller{
 		marshaller: marshaller,
 	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
 }
 
 type eventStreamMarshaller struct {
 	marshaller *runtime.JSONPb
+
+	idField    protoreflect.Name
+	idSequence *atomic.Uint64
+	retry      time.Duration
 }
 
 func (e *eventStreamMarshaller) encode(v interface{}) ([]byte, error) {
@@ -24,26 +69,86 @@ func (e *eventStreamMarshaller) encode(v interface{}) ([]byte, error) {
 		return nil, err
 	}
 	var b bytes.Buffer
-	b.WriteString("data: ")
-	b.Write(data)
-	b.WriteRune('\n')
+	if id, ok := e.eventID(v); ok {
+		b.WriteString("id: ")
+		b.WriteString(id)
+		b.WriteRune('\n')
+	}
+	if isStreamErrorChunk(v) {
+		b.WriteString("event: ")
+		b.WriteString(eventStreamErrorEvent)
+		b.WriteRune('\n')
+	}
+	if e.retry > 0 {
+		b.WriteString("retry: ")
+		b.WriteString(strconv.FormatInt(e.retry.Milliseconds(), 10))
+		b.WriteRune('\n')
+	}
+	// Every line of multi-line data needs its own field.
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		b.WriteString("data: ")
+		b.Write(line)
+		b.WriteRune('\n')
+	}
 	return b.Bytes(), nil
 }
 
-func (e *eventStreamMarshaller) Marshal(v interface{}) ([]byte, error) {
-	return e.encode(v)
+func (e *eventStreamMarshaller) eventID(v interface{}) (string, bool) {
+	if e.idSequence != nil {
+		return strconv.FormatUint(e.idSequence.Add(1), 10), true
+	}
+	if e.idField == "" {
+		return "", false
+	}
+
+	chunk, ok := v.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	msg, ok := chunk[streamingResponseResultKey].(proto.Message)
+	if !ok {
+		return "", false
+	}
+	m := msg.ProtoReflect()
+	fd := m.Descriptor().Fields().ByName(e.idField)
+	if fd == nil || fd.IsList() || fd.IsMap() || fd.Message() != nil || !m.Has(fd) {
+		return "", false
+	}
+	return fmt.Sprint(m.Get(fd).Interface()), true
 }
 
-func (e *eventStreamMarshaller) Unmarshal(_ []byte, _ interface{}) error {
-	return errors.New("unsupported method: Unmarshal")
+// isStreamErrorChunk reports whether v is an error sent by the gateway in the
+// middle of a stream.
+func isStreamErrorChunk(v interface{}) bool {
+	chunk, ok := v.(map[string]proto.Message)
+	if !ok {
+		return false
+	}
+	_, ok = chunk[streamingResponseErrorKey]
+	return ok
 }
 
-func (e *eventStreamMarshaller) Decode(_ interface{}) error {
-	return errors.New("unsupported method: Decode")
+func (e *eventStreamMarshaller) Marshal(v interface{}) ([]byte, error) {
+	return e.encode(v)
 }
 
-func (e *eventStreamMarshaller) NewDecoder(_ io.Reader) runtime.Decoder {
-	return e
+// Unmarshal decodes the data of the first event in data into v.
+func (e *eventStreamMarshaller) Unmarshal(data []byte, v interface{}) error {
+	return e.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// NewDecoder returns a decoder that reads events from r, and decodes their
+// data into the given values. Unlike streams, the last event does not need to
+// be terminated by an empty line.
+func (e *eventStreamMarshaller) NewDecoder(r io.Reader) runtime.Decoder {
+	d := newEventStreamDecoder(io.MultiReader(r, strings.NewReader("\n\n")))
+	return runtime.DecoderFunc(func(v interface{}) error {
+		event, err := d.Decode()
+		if err != nil {
+			return err
+		}
+		return e.marshaller.Unmarshal(event.data, v)
+	})
 }
 
 func (e *eventStreamMarshaller) NewEncoder(w io.Writer) runtime.Encoder {
@@ -60,3 +165,57 @@ func (e *eventStreamMarshaller) NewEncoder(w io.Writer) runtime.Encoder {
 func (e *eventStreamMarshaller) ContentType(_ interface{}) string {
 	return "text/event-stream"
 }
+
+// eventStreamDecoder reads server-sent events. It is shared by the marshaller
+// and the streaming requests.
+type eventStreamDecoder struct {
+	d            *decoder.Decoder
+	r            *errorRecordingReader
+	initialRetry time.Duration
+}
+
+func newEventStreamDecoder(body io.Reader) *eventStreamDecoder {
+	r := &errorRecordingReader{r: body}
+	d := decoder.New(r)
+	return &eventStreamDecoder{
+		d:            d,
+		r:            r,
+		initialRetry: d.Retry(),
+	}
+}
+
+func (d *eventStreamDecoder) Decode() (*streamEvent, error) {
+	event, err := d.d.Decode()
+	if err != nil {
+		// The decoder reports read errors as the end of the stream.
+		if d.r.err != nil && !errors.Is(d.r.err, io.EOF) {
+			return nil, d.r.err
+		}
+		return nil, err
+	}
+	return &streamEvent{
+		id:    event.ID,
+		hasID: event.HasID,
+		name:  event.Name,
+		data:  []byte(event.GetData()),
+	}, nil
+}
+
+// retryHint returns the reconnection delay sent by the server, if any.
+func (d *eventStreamDecoder) retryHint() (time.Duration, bool) {
+	retry := d.d.Retry()
+	return retry, retry != d.initialRetry
+}
+
+type errorRecordingReader struct {
+	r   io.Reader
+	err error
+}
+
+func (r *errorRecordingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if err != nil {
+		r.err = err
+	}
+	return n, err
+}
@@ -2,11 +2,16 @@ package gateway_test
 
 import (
 	"bytes"
+	"io"
 	"testing"
+	"time"
 
 	"github.com/alevinval/sse/pkg/decoder"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/akuity/grpc-gateway-client/internal/test/gen/testv1"
@@ -44,3 +49,170 @@ func TestEventStreamMarshaller(t *testing.T) {
 		})
 	}
 }
+
+func TestEventStreamMarshaller_Options(t *testing.T) {
+	result := map[string]interface{}{
+		"result": &testv1.SendInvitationResponse{
+			Id: "some-id",
+		},
+	}
+	testSets := map[string]struct {
+		marshaller    *runtime.JSONPb
+		opts          []gateway.EventStreamOption
+		input         []interface{}
+		expectedIDs   []string
+		expectedNames []string
+		expectedRetry time.Duration
+	}{
+		"without options": {
+			input:         []interface{}{result},
+			expectedIDs:   []string{""},
+			expectedNames: []string{""},
+		},
+		"id from field": {
+			opts: []gateway.EventStreamOption{
+				gateway.WithEventIDField("id"),
+			},
+			input:         []interface{}{result},
+			expectedIDs:   []string{"some-id"},
+			expectedNames: []string{""},
+		},
+		"id from unknown field": {
+			opts: []gateway.EventStreamOption{
+				gateway.WithEventIDField("unknown"),
+			},
+			input:         []interface{}{result},
+			expectedIDs:   []string{""},
+			expectedNames: []string{""},
+		},
+		"id from sequence": {
+			opts: []gateway.EventStreamOption{
+				gateway.WithEventIDSequence(),
+			},
+			input:         []interface{}{result, result},
+			expectedIDs:   []string{"1", "2"},
+			expectedNames: []string{"", ""},
+		},
+		"error event": {
+			input: []interface{}{
+				map[string]proto.Message{
+					"error": status.New(codes.Internal, "some error").Proto(),
+				},
+			},
+			expectedIDs:   []string{""},
+			expectedNames: []string{"error"},
+		},
+		"retry": {
+			opts: []gateway.EventStreamOption{
+				gateway.WithEventRetry(3 * time.Second),
+			},
+			input:         []interface{}{result},
+			expectedIDs:   []string{""},
+			expectedNames: []string{""},
+			expectedRetry: 3 * time.Second,
+		},
+		"multi-line data": {
+			marshaller: &runtime.JSONPb{
+				MarshalOptions: protojson.MarshalOptions{
+					Multiline: true,
+				},
+			},
+			input:         []interface{}{result},
+			expectedIDs:   []string{""},
+			expectedNames: []string{""},
+		},
+	}
+	for name, ts := range testSets {
+		t.Run(name, func(t *testing.T) {
+			jm := ts.marshaller
+			if jm == nil {
+				jm = &runtime.JSONPb{}
+			}
+			m := gateway.NewEventStreamMarshaller(jm, ts.opts...)
+			var buf bytes.Buffer
+			for _, v := range ts.input {
+				data, err := m.Marshal(v)
+				require.NoError(t, err)
+				buf.Write(data)
+				buf.WriteRune('\n') // Add empty line to the end
+			}
+
+			dec := decoder.New(&buf)
+			for idx, v := range ts.input {
+				event, err := dec.Decode()
+				require.NoError(t, err)
+				require.Equal(t, ts.expectedIDs[idx], event.ID)
+				require.Equal(t, ts.expectedNames[idx], event.Name)
+
+				expected, err := jm.Marshal(v)
+				require.NoError(t, err)
+				require.JSONEq(t, string(expected), event.GetData())
+			}
+			if ts.expectedRetry > 0 {
+				require.Equal(t, ts.expectedRetry, dec.Retry())
+			}
+		})
+	}
+}
+
+func TestEventStreamMarshaller_Decoder(t *testing.T) {
+	testSets := map[string]struct {
+		marshaller *runtime.JSONPb
+		opts       []gateway.EventStreamOption
+	}{
+		"single-line data": {
+			marshaller: &runtime.JSONPb{},
+		},
+		"multi-line data": {
+			marshaller: &runtime.JSONPb{
+				MarshalOptions: protojson.MarshalOptions{
+					Multiline: true,
+				},
+			},
+		},
+		"with event fields": {
+			marshaller: &runtime.JSONPb{},
+			opts: []gateway.EventStreamOption{
+				gateway.WithEventIDSequence(),
+				gateway.WithEventRetry(time.Second),
+			},
+		},
+	}
+	input := []proto.Message{
+		&testv1.SendInvitationResponse{
+			Id: "some-id",
+		},
+		&testv1.SendInvitationResponse{
+			Id: "other-id",
+		},
+	}
+	for name, ts := range testSets {
+		t.Run(name, func(t *testing.T) {
+			m := gateway.NewEventStreamMarshaller(ts.marshaller, ts.opts...)
+
+			// Unmarshal
+			data, err := m.Marshal(input[0])
+			require.NoError(t, err)
+			actual := &testv1.SendInvitationResponse{}
+			require.NoError(t, m.Unmarshal(data, actual))
+			require.True(t, proto.Equal(input[0], actual))
+
+			// Decoder
+			var buf bytes.Buffer
+			enc := m.NewEncoder(&buf)
+			for idx, msg := range input {
+				require.NoError(t, enc.Encode(msg))
+				if idx < len(input)-1 {
+					buf.WriteRune('\n')
+				}
+			}
+			dec := m.NewDecoder(&buf)
+			for _, msg := range input {
+				actual := &testv1.SendInvitationResponse{}
+				require.NoError(t, dec.Decode(actual))
+				require.True(t, proto.Equal(msg, actual))
+			}
+			require.ErrorIs(t, dec.Decode(&testv1.SendInvitationResponse{}), io.EOF)
+		})
+	}
+}
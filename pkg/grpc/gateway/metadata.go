@@ -0,0 +1,104 @@
+package gateway
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/metadata"
+
+	ctxutil "github.com/akuity/grpc-gateway-client/pkg/context"
+)
+
+const binaryMetadataSuffix = "-bin"
+
+// OutgoingMetadataMatcher returns the HTTP header that the outgoing gRPC
+// metadata key is sent as, or false if the key must not be sent.
+type OutgoingMetadataMatcher func(key string) (string, bool)
+
+// DefaultOutgoingMetadataMatcher sends every key as a Grpc-Metadata- header,
+// which grpc-gateway turns back into incoming metadata. Keys reserved by gRPC
+// are not sent.
+func DefaultOutgoingMetadataMatcher(key string) (string, bool) {
+	if strings.HasPrefix(key, "grpc-") || strings.HasPrefix(key, ":") {
+		return "", false
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+// AllowOutgoingMetadata returns a matcher that only sends the given keys, as
+// DefaultOutgoingMetadataMatcher does.
+func AllowOutgoingMetadata(keys ...string) OutgoingMetadataMatcher {
+	allowed := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		allowed[strings.ToLower(k)] = true
+	}
+	return func(key string) (string, bool) {
+		if !allowed[key] {
+			return "", false
+		}
+		return DefaultOutgoingMetadataMatcher(key)
+	}
+}
+
+type outgoingHeaderKey struct {
+	/* explicitly empty */
+}
+
+// AppendToOutgoingHeader returns a context that sends the given headers as is
+// with the requests of the calls made with it, unlike the outgoing metadata
+// which is sent as Grpc-Metadata- headers. Interceptors use it to send headers
+// such as the ones of trace propagators.
+func AppendToOutgoingHeader(ctx context.Context, header http.Header) context.Context {
+	merged := getOutgoingHeader(ctx).Clone()
+	if merged == nil {
+		merged = make(http.Header, len(header))
+	}
+	for k, vs := range header {
+		for _, v := range vs {
+			merged.Add(k, v)
+		}
+	}
+	return ctxutil.Set(ctx, outgoingHeaderKey{}, merged)
+}
+
+func getOutgoingHeader(ctx context.Context) http.Header {
+	header, _ := ctxutil.Get[outgoingHeaderKey, http.Header](ctx, outgoingHeaderKey{})
+	return header
+}
+
+// setOutgoingHeader sends the outgoing headers of the context, replacing the
+// ones of the request.
+func setOutgoingHeader(ctx context.Context, header http.Header) {
+	for k, vs := range getOutgoingHeader(ctx) {
+		header[k] = append([]string(nil), vs...)
+	}
+}
+
+// setOutgoingMetadata sends the outgoing metadata of the context as headers.
+func setOutgoingMetadata(ctx context.Context, header http.Header, matcher OutgoingMetadataMatcher) {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		return
+	}
+	for key, values := range md {
+		name, ok := matcher(key)
+		if !ok {
+			continue
+		}
+		for _, v := range values {
+			addMetadataHeader(header, name, key, v)
+		}
+	}
+}
+
+// addMetadataHeader sends the value of the metadata key as the given header.
+// Values of binary keys are base64-encoded, as grpc-gateway expects.
+func addMetadataHeader(header http.Header, name, key, v string) {
+	if strings.HasSuffix(key, binaryMetadataSuffix) {
+		v = base64.StdEncoding.EncodeToString([]byte(v))
+	}
+	header.Add(name, v)
+}
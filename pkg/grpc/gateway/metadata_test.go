@@ -0,0 +1,102 @@
+package gateway_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/akuity/grpc-gateway-client/pkg/grpc/gateway"
+)
+
+func TestOutgoingMetadata(t *testing.T) {
+	md := metadata.Pairs(
+		"tenant", "akuity",
+		"request-id", "1",
+		"request-id", "2",
+		"trace-bin", "\x00\x01\xff",
+		"grpc-internal", "x",
+	)
+	testSets := map[string]struct {
+		opts     []gateway.ClientOption
+		expected metadata.MD
+	}{
+		"default": {
+			expected: metadata.Pairs(
+				"tenant", "akuity",
+				"request-id", "1",
+				"request-id", "2",
+				"trace-bin", "\x00\x01\xff",
+			),
+		},
+		"allowlist": {
+			opts: []gateway.ClientOption{
+				gateway.WithOutgoingMetadataMatcher(gateway.AllowOutgoingMetadata("Tenant")),
+			},
+			expected: metadata.Pairs(
+				"tenant", "akuity",
+			),
+		},
+		"mapping": {
+			opts: []gateway.ClientOption{
+				gateway.WithOutgoingMetadataMatcher(func(key string) (string, bool) {
+					if key == "request-id" {
+						return "Grpc-Metadata-Correlation-Id", true
+					}
+					return "", false
+				}),
+			},
+			expected: metadata.Pairs(
+				"correlation-id", "1",
+				"correlation-id", "2",
+			),
+		},
+	}
+	for name, ts := range testSets {
+		t.Run(name, func(t *testing.T) {
+			var actual metadata.MD
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// Read the metadata the way grpc-gateway does.
+				ctx, err := runtime.AnnotateContext(r.Context(), runtime.NewServeMux(), r, "/test.v1.Test/Method")
+				require.NoError(t, err)
+				actual, _ = metadata.FromOutgoingContext(ctx)
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer srv.Close()
+			c := gateway.NewClient(srv.URL, ts.opts...)
+
+			ctx := metadata.NewOutgoingContext(context.Background(), md)
+			_, err := gateway.DoRequest[struct{}](ctx, c.NewRequest(http.MethodGet, "/"))
+			require.NoError(t, err)
+			for k, v := range ts.expected {
+				require.Equal(t, v, actual.Get(k), k)
+			}
+			require.Empty(t, actual.Get("grpc-internal"))
+		})
+	}
+}
+
+func TestAppendToOutgoingHeader(t *testing.T) {
+	var header http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	c := gateway.NewClient(srv.URL)
+
+	ctx := gateway.AppendToOutgoingHeader(context.Background(), http.Header{"Traceparent": {"00-1-2-01"}})
+	ctx = gateway.AppendToOutgoingHeader(ctx, http.Header{"baggage": {"tenant=akuity"}})
+	req := c.NewRequest(http.MethodGet, "/").SetHeader("Traceparent", "replaced")
+	_, err := gateway.DoRequest[struct{}](ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, []string{"00-1-2-01"}, header.Values("Traceparent"))
+	require.Equal(t, []string{"tenant=akuity"}, header.Values("Baggage"))
+	require.Empty(t, header.Values(runtime.MetadataHeaderPrefix+"traceparent"))
+}
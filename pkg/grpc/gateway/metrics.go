@@ -0,0 +1,112 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/akuity/grpc-gateway-client/pkg/grpc/gateway/internal/streamobserver"
+)
+
+// MetricsRecorder receives the metrics of the calls of the generated clients.
+// It must be safe for concurrent use.
+type MetricsRecorder interface {
+	// CallStarted is called when a call starts.
+	CallStarted(ctx context.Context, method string)
+	// CallFinished is called when a unary call returns, or when a stream ends.
+	CallFinished(ctx context.Context, stats CallStats)
+}
+
+// CallStats are the metrics of a finished call.
+type CallStats struct {
+	// Method is the full gRPC method name, e.g. "/package.Service/Method".
+	Method string
+	// Code is the code of the error of the call, OK if it succeeded.
+	Code codes.Code
+	// Duration is the time from the start of the call to its end, retries
+	// included. Streams end when their last message is received.
+	Duration time.Duration
+	// Streaming is true for server and bidirectional streams.
+	Streaming bool
+	// SentMessages and ReceivedMessages are the numbers of messages of the
+	// call.
+	SentMessages     int
+	ReceivedMessages int
+	// RequestSize and ResponseSize are the total protobuf-encoded sizes of the
+	// messages sent and received, as native gRPC clients report them.
+	// Requests streamed from a reader are not counted.
+	RequestSize  int
+	ResponseSize int
+}
+
+// messageSize returns the protobuf-encoded size of the message, or 0 if it is
+// not a protobuf message.
+func messageSize(m any) int {
+	if msg, ok := m.(proto.Message); ok {
+		return proto.Size(msg)
+	}
+	return 0
+}
+
+func newMetricsUnaryInterceptor(r MetricsRecorder) UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, c Client, invoker UnaryInvoker) error {
+		r.CallStarted(ctx, method)
+		start := time.Now()
+		err := invoker(ctx, method, req, reply)
+		stats := CallStats{
+			Method:   method,
+			Code:     status.Code(err),
+			Duration: time.Since(start),
+		}
+		if req != nil {
+			stats.SentMessages = 1
+			stats.RequestSize = messageSize(req)
+		}
+		if err == nil {
+			stats.ReceivedMessages = 1
+			stats.ResponseSize = messageSize(reply)
+		}
+		r.CallFinished(ctx, stats)
+		return err
+	}
+}
+
+func newMetricsStreamInterceptor(r MetricsRecorder) StreamClientInterceptor {
+	return func(ctx context.Context, method string, req any, c Client, streamer Streamer) (ClientStream, error) {
+		r.CallStarted(ctx, method)
+		start := time.Now()
+		stats := CallStats{
+			Method:    method,
+			Streaming: true,
+		}
+		if req != nil {
+			stats.SentMessages = 1
+			stats.RequestSize = messageSize(req)
+		}
+		finish := func(err error) {
+			stats.Code = status.Code(err)
+			stats.Duration = time.Since(start)
+			r.CallFinished(ctx, stats)
+		}
+
+		cs, err := streamer(ctx, method, req)
+		if err != nil {
+			finish(err)
+			return nil, err
+		}
+		return streamobserver.New(ctx, cs, streamobserver.Observer{
+			Sent: func(m any) {
+				stats.SentMessages++
+				stats.RequestSize += messageSize(m)
+			},
+			Received: func(m any) {
+				stats.ReceivedMessages++
+				stats.ResponseSize += messageSize(m)
+			},
+			End: finish,
+		}), nil
+	}
+}
@@ -0,0 +1,115 @@
+package gateway_test
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/akuity/grpc-gateway-client/internal/test/gen/testv1"
+	"github.com/akuity/grpc-gateway-client/internal/test/server"
+	"github.com/akuity/grpc-gateway-client/pkg/grpc/gateway"
+)
+
+type fakeMetricsRecorder struct {
+	mu       sync.Mutex
+	started  []string
+	finished []gateway.CallStats
+}
+
+func (r *fakeMetricsRecorder) CallStarted(_ context.Context, method string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.started = append(r.started, method)
+}
+
+func (r *fakeMetricsRecorder) CallFinished(_ context.Context, stats gateway.CallStats) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stats.Duration = 0
+	r.finished = append(r.finished, stats)
+}
+
+func (s *RequestTestSuite) TestMetricsRecorder() {
+	recorder := &fakeMetricsRecorder{}
+	client := testv1.NewTestServiceGatewayClient(gateway.NewClient(s.gwSrv.URL,
+		gateway.WithMetricsRecorder(recorder),
+	))
+
+	req := &testv1.SendInvitationRequest{
+		Email: "test@test.com",
+	}
+	res, err := client.SendInvitation(context.TODO(), req)
+	s.Require().NoError(err)
+	_, err = client.SendInvitation(context.TODO(), &testv1.SendInvitationRequest{})
+	s.Require().Error(err)
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+	resCh, errCh, err := client.TrackInvitation(ctx, &testv1.TrackInvitationRequest{
+		Id: server.ExpiredInvitationID,
+	})
+	s.Require().NoError(err)
+	var tracked []*testv1.TrackInvitationResponse
+	for res := range resCh {
+		tracked = append(tracked, res)
+	}
+	s.Require().Error(<-errCh)
+
+	stream, err := client.DiscussInvitation(ctx)
+	s.Require().NoError(err)
+	discussReq := &testv1.DiscussInvitationRequest{
+		Message: "hello",
+	}
+	s.Require().NoError(stream.Send(discussReq))
+	discussRes, err := stream.Recv()
+	s.Require().NoError(err)
+	s.Require().NoError(stream.CloseSend())
+	_, err = stream.Recv()
+	s.Require().ErrorIs(err, io.EOF)
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	s.Require().Equal([]string{
+		"/io.akuity.test.v1.TestService/SendInvitation",
+		"/io.akuity.test.v1.TestService/SendInvitation",
+		"/io.akuity.test.v1.TestService/TrackInvitation",
+		"/io.akuity.test.v1.TestService/DiscussInvitation",
+	}, recorder.started)
+	s.Require().Equal([]gateway.CallStats{
+		{
+			Method:           "/io.akuity.test.v1.TestService/SendInvitation",
+			Code:             codes.OK,
+			SentMessages:     1,
+			ReceivedMessages: 1,
+			RequestSize:      proto.Size(req),
+			ResponseSize:     proto.Size(res),
+		},
+		{
+			Method:       "/io.akuity.test.v1.TestService/SendInvitation",
+			Code:         codes.InvalidArgument,
+			SentMessages: 1,
+		},
+		{
+			Method:           "/io.akuity.test.v1.TestService/TrackInvitation",
+			Code:             codes.FailedPrecondition,
+			Streaming:        true,
+			SentMessages:     1,
+			ReceivedMessages: 1,
+			RequestSize:      proto.Size(&testv1.TrackInvitationRequest{Id: server.ExpiredInvitationID}),
+			ResponseSize:     proto.Size(tracked[0]),
+		},
+		{
+			Method:           "/io.akuity.test.v1.TestService/DiscussInvitation",
+			Code:             codes.OK,
+			Streaming:        true,
+			SentMessages:     1,
+			ReceivedMessages: 1,
+			RequestSize:      proto.Size(discussReq),
+			ResponseSize:     proto.Size(discussRes),
+		},
+	}, recorder.finished)
+}
@@ -1,9 +1,17 @@
 package gateway
 
 import (
+	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/akuity/grpc-gateway-client/pkg/http/roundtripper"
 )
 
 type ClientOption func(*client)
@@ -25,3 +33,150 @@ func SkipTLSVerify(skip bool) ClientOption {
 		c.skipTLSVerify = skip
 	}
 }
+
+// WithHTTPBodyChunkSize sets the maximum size of the google.api.HttpBody chunks
+// delivered by streaming requests. Defaults to 32KiB.
+func WithHTTPBodyChunkSize(size int) ClientOption {
+	return func(c *client) {
+		c.httpBodyChunkSize = size
+	}
+}
+
+// WithStreamIdleTimeout fails streams, including the bodies returned by
+// DoHTTPBodyStreamingRequest, with codes.DeadlineExceeded when the client
+// waits longer than the timeout for data, keepalives included. The time that
+// the consumer takes to handle the messages does not count. Streams of
+// server-sent events with a reconnect policy are resumed instead.
+func WithStreamIdleTimeout(timeout time.Duration) ClientOption {
+	return func(c *client) {
+		c.streamIdleTimeout = timeout
+	}
+}
+
+// WithStreamMaxDuration fails streams with codes.DeadlineExceeded once they
+// last longer than d, reconnections included.
+func WithStreamMaxDuration(d time.Duration) ClientOption {
+	return func(c *client) {
+		c.streamMaxDuration = d
+	}
+}
+
+// WithHTTPStatusToCode overrides how the HTTP status of error responses that
+// carry no gRPC status is converted to gRPC error code. Defaults to
+// HTTPStatusToCode.
+func WithHTTPStatusToCode(fn func(status int) codes.Code) ClientOption {
+	return func(c *client) {
+		c.httpStatusToCode = fn
+	}
+}
+
+// WithRetryPolicy retries the requests that fail with one of the retryable
+// codes of the policy. Only requests with an idempotent HTTP method or marked
+// with SetIdempotent are retried, unless the policy says otherwise.
+func WithRetryPolicy(p RetryPolicy) ClientOption {
+	return func(c *client) {
+		c.retryPolicy = &p
+	}
+}
+
+// WithGRPCTimeoutMargin sends the Grpc-Timeout header with the time left until
+// the deadline of the request context less the margin, so that the server
+// gives up before the client and its error can still be received.
+func WithGRPCTimeoutMargin(margin time.Duration) ClientOption {
+	return func(c *client) {
+		c.grpcTimeoutMargin = margin
+	}
+}
+
+// WithOutgoingMetadataMatcher controls which keys of the outgoing gRPC metadata
+// of the request context are sent, and as which headers. Defaults to
+// DefaultOutgoingMetadataMatcher.
+func WithOutgoingMetadataMatcher(m OutgoingMetadataMatcher) ClientOption {
+	return func(c *client) {
+		c.metadataMatcher = m
+	}
+}
+
+// WithUnaryInterceptor adds interceptors to the unary calls of the generated
+// clients. The first interceptor is the outermost one.
+func WithUnaryInterceptor(interceptors ...UnaryClientInterceptor) ClientOption {
+	return func(c *client) {
+		c.unaryInterceptors = append(c.unaryInterceptors, interceptors...)
+	}
+}
+
+// WithStreamInterceptor adds interceptors to the streaming calls of the
+// generated clients. The first interceptor is the outermost one.
+func WithStreamInterceptor(interceptors ...StreamClientInterceptor) ClientOption {
+	return func(c *client) {
+		c.streamInterceptors = append(c.streamInterceptors, interceptors...)
+	}
+}
+
+// WithMetricsRecorder reports the metrics of the calls of the generated
+// clients to the recorder. It is an interceptor, so it sees the calls as
+// shaped by the interceptors added before it.
+func WithMetricsRecorder(r MetricsRecorder) ClientOption {
+	return func(c *client) {
+		c.unaryInterceptors = append(c.unaryInterceptors, newMetricsUnaryInterceptor(r))
+		c.streamInterceptors = append(c.streamInterceptors, newMetricsStreamInterceptor(r))
+	}
+}
+
+// WithLogger logs the calls of the generated clients with their method, route,
+// status and duration.
+func WithLogger(l *slog.Logger) ClientOption {
+	return func(c *client) {
+		c.log.logger = l
+	}
+}
+
+// WithPayloadLogging also logs the messages and the headers of the calls, once
+// the fields marked with the debug_redact option or WithRedactedFields are
+// redacted. Credential headers, including the ones of the per-RPC
+// credentials, are always masked. Stream messages are logged
+// at the debug level.
+func WithPayloadLogging() ClientOption {
+	return func(c *client) {
+		c.log.payloads = true
+	}
+}
+
+// WithRedactedFields redacts the given fields from the logged messages, in
+// addition to the ones marked with the debug_redact option.
+func WithRedactedFields(fields ...protoreflect.FullName) ClientOption {
+	return func(c *client) {
+		if c.log.redactedFields == nil {
+			c.log.redactedFields = make(map[protoreflect.FullName]bool, len(fields))
+		}
+		for _, f := range fields {
+			c.log.redactedFields[f] = true
+		}
+	}
+}
+
+// WithTokenSource authorizes the requests with the tokens of the source.
+// Tokens are cached and refreshed before they expire. Requests rejected as
+// Unauthenticated are sent again once with a new token, provided that the
+// source gets a new token on every call, see
+// roundtripper.RefreshingTokenSource.
+func WithTokenSource(ts oauth2.TokenSource) ClientOption {
+	return func(c *client) {
+		c.tokenSource = roundtripper.NewRefreshingTokenSource(ts)
+	}
+}
+
+// WithPerRPCCredentials sends the metadata of the credentials as headers with
+// every request, so that the credentials of gRPC clients can be shared. Like
+// gRPC, the credentials get the URI of the service of the called method, and
+// those that require transport security are not sent over plain HTTP. The
+// requests sent with DoRequest and DoStreamingRequest rather than with the
+// generated clients get the URI of the host only, since their method is not
+// known. Credentials that check the security level of the connection with
+// credentials.RequestInfoFromContext cannot be used, since gRPC provides no
+// way to set it outside of its transport.
+func WithPerRPCCredentials(creds ...credentials.PerRPCCredentials) ClientOption {
+	return func(c *client) {
+		c.perRPCCreds = append(c.perRPCCreds, creds...)
+	}
+}
@@ -0,0 +1,80 @@
+package otelgateway
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+
+	"github.com/akuity/grpc-gateway-client/pkg/grpc/gateway"
+)
+
+// activeRequestsName is the name of the in-flight calls metric, after the
+// http.client.active_requests one of the semantic conventions.
+const activeRequestsName = "rpc.client.active_requests"
+
+var _ gateway.MetricsRecorder = &metricsRecorder{}
+
+type metricsRecorder struct {
+	duration        metric.Float64Histogram
+	requestSize     metric.Int64Histogram
+	responseSize    metric.Int64Histogram
+	requestsPerRPC  metric.Int64Histogram
+	responsesPerRPC metric.Int64Histogram
+	activeRequests  metric.Int64UpDownCounter
+}
+
+// NewMetricsRecorder returns a recorder of the RPC client metrics of the
+// semantic conventions, for gateway.WithMetricsRecorder. The request and
+// response sizes of streams are the total sizes of their messages.
+func NewMetricsRecorder(opts ...Option) (gateway.MetricsRecorder, error) {
+	meter := newConfig(opts).meterProvider.Meter(ScopeName)
+	r := &metricsRecorder{}
+	var err, errs error
+	r.duration, err = meter.Float64Histogram(semconv.RPCClientDurationName,
+		metric.WithUnit(semconv.RPCClientDurationUnit),
+		metric.WithDescription(semconv.RPCClientDurationDescription))
+	errs = errors.Join(errs, err)
+	r.requestSize, err = meter.Int64Histogram(semconv.RPCClientRequestSizeName,
+		metric.WithUnit(semconv.RPCClientRequestSizeUnit),
+		metric.WithDescription(semconv.RPCClientRequestSizeDescription))
+	errs = errors.Join(errs, err)
+	r.responseSize, err = meter.Int64Histogram(semconv.RPCClientResponseSizeName,
+		metric.WithUnit(semconv.RPCClientResponseSizeUnit),
+		metric.WithDescription(semconv.RPCClientResponseSizeDescription))
+	errs = errors.Join(errs, err)
+	r.requestsPerRPC, err = meter.Int64Histogram(semconv.RPCClientRequestsPerRPCName,
+		metric.WithUnit(semconv.RPCClientRequestsPerRPCUnit),
+		metric.WithDescription("Measures the number of messages sent per RPC."))
+	errs = errors.Join(errs, err)
+	r.responsesPerRPC, err = meter.Int64Histogram(semconv.RPCClientResponsesPerRPCName,
+		metric.WithUnit(semconv.RPCClientResponsesPerRPCUnit),
+		metric.WithDescription("Measures the number of messages received per RPC."))
+	errs = errors.Join(errs, err)
+	r.activeRequests, err = meter.Int64UpDownCounter(activeRequestsName,
+		metric.WithUnit("{request}"),
+		metric.WithDescription("Number of outbound RPCs in flight."))
+	errs = errors.Join(errs, err)
+	if errs != nil {
+		return nil, errs
+	}
+	return r, nil
+}
+
+func (r *metricsRecorder) CallStarted(ctx context.Context, method string) {
+	r.activeRequests.Add(ctx, 1, metric.WithAttributes(methodAttributes(method)...))
+}
+
+func (r *metricsRecorder) CallFinished(ctx context.Context, stats gateway.CallStats) {
+	attrs := methodAttributes(stats.Method)
+	r.activeRequests.Add(ctx, -1, metric.WithAttributes(attrs...))
+
+	opt := metric.WithAttributes(append(attrs, semconv.RPCGRPCStatusCodeKey.Int(int(stats.Code)))...)
+	r.duration.Record(ctx, float64(stats.Duration)/float64(time.Millisecond), opt)
+	r.requestSize.Record(ctx, int64(stats.RequestSize), opt)
+	r.responseSize.Record(ctx, int64(stats.ResponseSize), opt)
+	r.requestsPerRPC.Record(ctx, int64(stats.SentMessages), opt)
+	r.responsesPerRPC.Record(ctx, int64(stats.ReceivedMessages), opt)
+}
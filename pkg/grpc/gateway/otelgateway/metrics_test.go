@@ -0,0 +1,76 @@
+package otelgateway_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"google.golang.org/grpc/codes"
+
+	"github.com/akuity/grpc-gateway-client/pkg/grpc/gateway"
+	"github.com/akuity/grpc-gateway-client/pkg/grpc/gateway/otelgateway"
+)
+
+func TestMetricsRecorder(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	recorder, err := otelgateway.NewMetricsRecorder(otelgateway.WithMeterProvider(
+		sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	))
+	require.NoError(t, err)
+
+	method := "/io.akuity.test.v1.TestService/TrackInvitation"
+	recorder.CallStarted(context.TODO(), method)
+	recorder.CallStarted(context.TODO(), method)
+	recorder.CallFinished(context.TODO(), gateway.CallStats{
+		Method:           method,
+		Code:             codes.FailedPrecondition,
+		Duration:         1500 * time.Microsecond,
+		Streaming:        true,
+		SentMessages:     1,
+		ReceivedMessages: 3,
+		RequestSize:      10,
+		ResponseSize:     42,
+	})
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.TODO(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	require.Equal(t, otelgateway.ScopeName, rm.ScopeMetrics[0].Scope.Name)
+	metrics := map[string]metricdata.Aggregation{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m.Data
+	}
+
+	methodAttrs := []attribute.KeyValue{
+		semconv.RPCSystemGRPC,
+		semconv.RPCService("io.akuity.test.v1.TestService"),
+		semconv.RPCMethod("TrackInvitation"),
+	}
+	active := metrics["rpc.client.active_requests"].(metricdata.Sum[int64])
+	require.Len(t, active.DataPoints, 1)
+	require.Equal(t, int64(1), active.DataPoints[0].Value)
+	require.Equal(t, attribute.NewSet(methodAttrs...), active.DataPoints[0].Attributes)
+
+	attrs := attribute.NewSet(append(methodAttrs, semconv.RPCGRPCStatusCodeKey.Int(int(codes.FailedPrecondition)))...)
+	duration := metrics[semconv.RPCClientDurationName].(metricdata.Histogram[float64])
+	require.Len(t, duration.DataPoints, 1)
+	require.Equal(t, attrs, duration.DataPoints[0].Attributes)
+	require.Equal(t, 1.5, duration.DataPoints[0].Sum)
+
+	for name, expected := range map[string]int64{
+		semconv.RPCClientRequestSizeName:     10,
+		semconv.RPCClientResponseSizeName:    42,
+		semconv.RPCClientRequestsPerRPCName:  1,
+		semconv.RPCClientResponsesPerRPCName: 3,
+	} {
+		h := metrics[name].(metricdata.Histogram[int64])
+		require.Len(t, h.DataPoints, 1, name)
+		require.Equal(t, attrs, h.DataPoints[0].Attributes, name)
+		require.Equal(t, expected, h.DataPoints[0].Sum, name)
+	}
+}
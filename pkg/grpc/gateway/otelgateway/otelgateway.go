@@ -0,0 +1,218 @@
+// Package otelgateway instruments the gateway clients with OpenTelemetry
+// tracing and metrics, like otelgrpc does for gRPC clients.
+package otelgateway
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/akuity/grpc-gateway-client/pkg/grpc/gateway"
+	gwerrors "github.com/akuity/grpc-gateway-client/pkg/grpc/gateway/errors"
+	"github.com/akuity/grpc-gateway-client/pkg/grpc/gateway/internal/streamobserver"
+	"github.com/akuity/grpc-gateway-client/pkg/http/roundtripper"
+)
+
+// ScopeName is the instrumentation scope name of the tracer and the meter.
+const ScopeName = "github.com/akuity/grpc-gateway-client/pkg/grpc/gateway/otelgateway"
+
+// messageEvent is the name of the span events of stream messages.
+const messageEvent = "message"
+
+type Option func(*config)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagators    propagation.TextMapPropagator
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagators:    otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithTracerProvider sets the tracer provider. Defaults to the global one.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider sets the meter provider. Defaults to the global one.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = mp
+	}
+}
+
+// WithPropagators sets the propagators that inject the span context into the
+// requests. Defaults to the global ones.
+func WithPropagators(p propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagators = p
+	}
+}
+
+// UnaryClientInterceptor starts a client span for every unary call, and
+// injects its context into the headers of the requests. The span records the
+// HTTP status of the response.
+func UnaryClientInterceptor(opts ...Option) gateway.UnaryClientInterceptor {
+	cfg := newConfig(opts)
+	tracer := cfg.tracerProvider.Tracer(ScopeName)
+	return func(ctx context.Context, method string, req, reply any, c gateway.Client, invoker gateway.UnaryInvoker) error {
+		ctx, span := startSpan(ctx, tracer, cfg.propagators, method)
+		defer span.End()
+
+		var httpStatus int
+		err := invoker(gateway.CaptureStatusCode(ctx, &httpStatus), method, req, reply)
+		setHTTPStatus(span, httpStatus)
+		setStatus(span, err)
+		return err
+	}
+}
+
+// StreamClientInterceptor starts a client span for every streaming call, and
+// injects its context into the headers of the requests. The span records the
+// HTTP status of the response, ends with the stream, and records an event for
+// every message.
+func StreamClientInterceptor(opts ...Option) gateway.StreamClientInterceptor {
+	cfg := newConfig(opts)
+	tracer := cfg.tracerProvider.Tracer(ScopeName)
+	return func(ctx context.Context, method string, req any, c gateway.Client, streamer gateway.Streamer) (gateway.ClientStream, error) {
+		ctx, span := startSpan(ctx, tracer, cfg.propagators, method)
+		var httpStatus int
+		cs, err := streamer(gateway.CaptureStatusCode(ctx, &httpStatus), method, req)
+		setHTTPStatus(span, httpStatus)
+		if err != nil {
+			setStatus(span, err)
+			span.End()
+			return nil, err
+		}
+
+		var sent, received int
+		return streamobserver.New(ctx, cs, streamobserver.Observer{
+			Sent: func(any) {
+				sent++
+				addMessageEvent(span, semconv.RPCMessageTypeSent, sent)
+			},
+			Received: func(any) {
+				received++
+				addMessageEvent(span, semconv.RPCMessageTypeReceived, received)
+			},
+			End: func(err error) {
+				setStatus(span, err)
+				span.End()
+			},
+		}), nil
+	}
+}
+
+// ApplyTransport wraps the transport of the HTTP client to inject the span
+// context of the requests into their headers, and to record the HTTP status
+// of the responses on the span. The interceptors do both without it, so it is
+// only needed to propagate the span context of the requests sent with
+// gateway.DoRequest.
+func ApplyTransport(client *http.Client, opts ...Option) {
+	client.Transport = &transport{
+		rt:          roundtripper.GetRoundTripper(client),
+		propagators: newConfig(opts).propagators,
+	}
+}
+
+var _ roundtripper.WrappedRoundTripper = &transport{}
+
+type transport struct {
+	rt          http.RoundTripper
+	propagators propagation.TextMapPropagator
+}
+
+func (t *transport) Unwrap() http.RoundTripper {
+	return t.rt
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	newReq := req.Clone(ctx)
+	t.propagators.Inject(ctx, propagation.HeaderCarrier(newReq.Header))
+	res, err := t.rt.RoundTrip(newReq)
+	if err == nil {
+		trace.SpanFromContext(ctx).SetAttributes(semconv.HTTPResponseStatusCode(res.StatusCode))
+	}
+	return res, err
+}
+
+func startSpan(ctx context.Context, tracer trace.Tracer, propagators propagation.TextMapPropagator, method string) (context.Context, trace.Span) {
+	ctx, span := tracer.Start(ctx, strings.TrimPrefix(method, "/"),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(methodAttributes(method)...),
+	)
+	header := http.Header{}
+	propagators.Inject(ctx, propagation.HeaderCarrier(header))
+	return gateway.AppendToOutgoingHeader(ctx, header), span
+}
+
+func methodAttributes(method string) []attribute.KeyValue {
+	service, rpc, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	return []attribute.KeyValue{
+		semconv.RPCSystemGRPC,
+		semconv.RPCService(service),
+		semconv.RPCMethod(rpc),
+	}
+}
+
+// setHTTPStatus records the HTTP status of the response, if one arrived.
+func setHTTPStatus(span trace.Span, httpStatus int) {
+	if httpStatus != 0 {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(httpStatus))
+	}
+}
+
+// setStatus records the gRPC code of the error, and the HTTP status of the
+// response it was read from.
+func setStatus(span trace.Span, err error) {
+	code := status.Code(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+	if httpStatus, ok := getHTTPStatusCode(err); ok {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(httpStatus))
+	}
+	if code != codes.OK {
+		span.SetStatus(otelcodes.Error, status.Convert(err).Message())
+	}
+}
+
+func getHTTPStatusCode(err error) (int, bool) {
+	if httpStatus, ok := gwerrors.HTTPStatusCode(err); ok && httpStatus != 0 {
+		return httpStatus, true
+	}
+	var httpErr *gateway.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode, true
+	}
+	return 0, false
+}
+
+func addMessageEvent(span trace.Span, messageType attribute.KeyValue, id int) {
+	span.AddEvent(messageEvent, trace.WithAttributes(
+		messageType,
+		semconv.RPCMessageID(id),
+	))
+}
@@ -0,0 +1,223 @@
+package otelgateway_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/akuity/grpc-gateway-client/internal/test/gen/testv1"
+	"github.com/akuity/grpc-gateway-client/internal/test/server"
+	"github.com/akuity/grpc-gateway-client/pkg/grpc/gateway"
+	"github.com/akuity/grpc-gateway-client/pkg/grpc/gateway/otelgateway"
+)
+
+// headerRecorder records the traceparent headers received by the gateway.
+type headerRecorder struct {
+	mu           sync.Mutex
+	traceparents []string
+}
+
+func (r *headerRecorder) wrap(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.mu.Lock()
+		r.traceparents = append(r.traceparents, req.Header.Get("Traceparent"))
+		r.mu.Unlock()
+		h.ServeHTTP(w, req)
+	})
+}
+
+func newTestClient(t *testing.T, applyTransport bool) (testv1.TestServiceGatewayClient, *tracetest.InMemoryExporter, *headerRecorder) {
+	l := bufconn.Listen(256 * 1024)
+	grpcSrv := grpc.NewServer()
+	testv1.RegisterTestServiceServer(grpcSrv, server.NewTestServer())
+	go func() {
+		_ = grpcSrv.Serve(l)
+	}()
+	t.Cleanup(grpcSrv.Stop)
+
+	cc, err := grpc.Dial("",
+		grpc.WithContextDialer(func(_ context.Context, _ string) (net.Conn, error) {
+			return l.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = cc.Close()
+	})
+
+	marshaller := &runtime.JSONPb{}
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption("application/json", marshaller),
+		runtime.WithMarshalerOption("text/event-stream", gateway.NewEventStreamMarshaller(marshaller)),
+	)
+	require.NoError(t, testv1.RegisterTestServiceHandler(context.TODO(), mux, cc))
+	recorder := &headerRecorder{}
+	gwSrv := httptest.NewServer(recorder.wrap(mux))
+	t.Cleanup(gwSrv.Close)
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	opts := []otelgateway.Option{
+		otelgateway.WithTracerProvider(tp),
+		otelgateway.WithPropagators(propagation.NewCompositeTextMapPropagator(
+			propagation.TraceContext{},
+			propagation.Baggage{},
+		)),
+	}
+	hc := &http.Client{}
+	if applyTransport {
+		otelgateway.ApplyTransport(hc, opts...)
+	}
+	client := testv1.NewTestServiceGatewayClient(gateway.NewClient(gwSrv.URL,
+		gateway.WithHTTPClient(hc),
+		gateway.WithUnaryInterceptor(otelgateway.UnaryClientInterceptor(opts...)),
+		gateway.WithStreamInterceptor(otelgateway.StreamClientInterceptor(opts...)),
+	))
+	return client, exporter, recorder
+}
+
+func getAttribute(span tracetest.SpanStub, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	client, exporter, recorder := newTestClient(t, true)
+
+	_, err := client.SendInvitation(context.TODO(), &testv1.SendInvitationRequest{
+		Email: "test@test.com",
+	})
+	require.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	require.Equal(t, "io.akuity.test.v1.TestService/SendInvitation", span.Name)
+	require.Equal(t, trace.SpanKindClient, span.SpanKind)
+	require.Equal(t, "grpc", getAttribute(span, semconv.RPCSystemKey).AsString())
+	require.Equal(t, "io.akuity.test.v1.TestService", getAttribute(span, semconv.RPCServiceKey).AsString())
+	require.Equal(t, "SendInvitation", getAttribute(span, semconv.RPCMethodKey).AsString())
+	require.Equal(t, int64(codes.OK), getAttribute(span, semconv.RPCGRPCStatusCodeKey).AsInt64())
+	require.Equal(t, int64(http.StatusOK), getAttribute(span, semconv.HTTPResponseStatusCodeKey).AsInt64())
+	require.Equal(t, otelcodes.Unset, span.Status.Code)
+
+	require.Len(t, recorder.traceparents, 1)
+	require.Equal(t, "00-"+span.SpanContext.TraceID().String()+"-"+span.SpanContext.SpanID().String()+"-01",
+		recorder.traceparents[0])
+}
+
+func TestUnaryClientInterceptor_Error(t *testing.T) {
+	client, exporter, _ := newTestClient(t, true)
+
+	_, err := client.SendInvitation(context.TODO(), &testv1.SendInvitationRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	require.Equal(t, int64(codes.InvalidArgument), getAttribute(span, semconv.RPCGRPCStatusCodeKey).AsInt64())
+	require.Equal(t, int64(http.StatusBadRequest), getAttribute(span, semconv.HTTPResponseStatusCodeKey).AsInt64())
+	require.Equal(t, otelcodes.Error, span.Status.Code)
+	require.Equal(t, "email is required", span.Status.Description)
+}
+
+func TestStreamClientInterceptor(t *testing.T) {
+	testSets := map[string]struct {
+		id           string
+		expectedCode codes.Code
+		expectedMsgs int
+	}{
+		"completed stream": {
+			id:           "some-id",
+			expectedCode: codes.OK,
+			expectedMsgs: 2,
+		},
+		"failed stream": {
+			id:           server.ExpiredInvitationID,
+			expectedCode: codes.FailedPrecondition,
+			expectedMsgs: 1,
+		},
+	}
+	for name, ts := range testSets {
+		t.Run(name, func(t *testing.T) {
+			client, exporter, recorder := newTestClient(t, true)
+
+			ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+			defer cancel()
+			resCh, errCh, err := client.TrackInvitation(ctx, &testv1.TrackInvitationRequest{
+				Id: ts.id,
+			})
+			require.NoError(t, err)
+			for range resCh {
+			}
+			require.Equal(t, ts.expectedCode, status.Code(<-errCh))
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			span := spans[0]
+			require.Equal(t, "io.akuity.test.v1.TestService/TrackInvitation", span.Name)
+			require.Equal(t, int64(ts.expectedCode), getAttribute(span, semconv.RPCGRPCStatusCodeKey).AsInt64())
+			require.Equal(t, int64(http.StatusOK), getAttribute(span, semconv.HTTPResponseStatusCodeKey).AsInt64())
+			require.Len(t, span.Events, ts.expectedMsgs)
+			for i, event := range span.Events {
+				require.Equal(t, "message", event.Name)
+				require.Contains(t, event.Attributes, semconv.RPCMessageTypeReceived)
+				require.Contains(t, event.Attributes, semconv.RPCMessageID(i+1))
+			}
+
+			require.Len(t, recorder.traceparents, 1)
+			require.Contains(t, recorder.traceparents[0], span.SpanContext.TraceID().String())
+		})
+	}
+}
+
+func TestInterceptors_WithoutTransport(t *testing.T) {
+	// The interceptors propagate the span context, and record the HTTP status
+	// of the responses, by themselves.
+	client, exporter, recorder := newTestClient(t, false)
+
+	_, err := client.SendInvitation(context.TODO(), &testv1.SendInvitationRequest{
+		Email: "test@test.com",
+	})
+	require.NoError(t, err)
+	resCh, errCh, err := client.TrackInvitation(context.TODO(), &testv1.TrackInvitationRequest{
+		Id: "some-id",
+	})
+	require.NoError(t, err)
+	for range resCh {
+	}
+	require.NoError(t, <-errCh)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	require.Len(t, recorder.traceparents, 2)
+	for i, span := range spans {
+		require.Equal(t, int64(http.StatusOK), getAttribute(span, semconv.HTTPResponseStatusCodeKey).AsInt64())
+		require.Equal(t, "00-"+span.SpanContext.TraceID().String()+"-"+span.SpanContext.SpanID().String()+"-01",
+			recorder.traceparents[i])
+	}
+}
@@ -0,0 +1,182 @@
+// Package promgateway exports the metrics of the gateway clients to
+// Prometheus, after the metrics of go-grpc-prometheus, with a
+// grpc_gateway_client_ prefix instead of grpc_client_.
+package promgateway
+
+import (
+	"context"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/akuity/grpc-gateway-client/pkg/grpc/gateway"
+)
+
+var (
+	_ gateway.MetricsRecorder = &Metrics{}
+	_ prometheus.Collector    = &Metrics{}
+)
+
+// subsystem prefixes the names of the metrics, so that they do not clash with
+// the ones of go-grpc-prometheus, whose labels differ, in processes that also
+// instrument gRPC clients.
+const subsystem = "grpc_gateway_client"
+
+type Option func(*config)
+
+type config struct {
+	namespace       string
+	durationBuckets []float64
+	sizeBuckets     []float64
+}
+
+// WithNamespace prefixes the names of the metrics with the namespace.
+func WithNamespace(namespace string) Option {
+	return func(c *config) {
+		c.namespace = namespace
+	}
+}
+
+// WithDurationBuckets sets the buckets of the call duration histogram, in
+// seconds. Defaults to prometheus.DefBuckets.
+func WithDurationBuckets(buckets []float64) Option {
+	return func(c *config) {
+		c.durationBuckets = buckets
+	}
+}
+
+// WithSizeBuckets sets the buckets of the request and response size
+// histograms, in bytes.
+func WithSizeBuckets(buckets []float64) Option {
+	return func(c *config) {
+		c.sizeBuckets = buckets
+	}
+}
+
+// Metrics is a gateway.MetricsRecorder that is also a prometheus.Collector of
+// the metrics it records.
+type Metrics struct {
+	started      *prometheus.CounterVec
+	handled      *prometheus.CounterVec
+	inFlight     *prometheus.GaugeVec
+	duration     *prometheus.HistogramVec
+	requestSize  *prometheus.HistogramVec
+	responseSize *prometheus.HistogramVec
+	msgSent      *prometheus.CounterVec
+	msgReceived  *prometheus.CounterVec
+}
+
+// NewMetrics returns the metrics, which must be registered to be exported.
+func NewMetrics(opts ...Option) *Metrics {
+	c := &config{
+		durationBuckets: prometheus.DefBuckets,
+		sizeBuckets:     prometheus.ExponentialBuckets(64, 4, 8),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	labels := []string{"grpc_service", "grpc_method"}
+	codeLabels := []string{"grpc_service", "grpc_method", "grpc_code"}
+	return &Metrics{
+		started: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: c.namespace,
+			Subsystem: subsystem,
+			Name:      "started_total",
+			Help:      "Total number of RPCs started by the client.",
+		}, labels),
+		handled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: c.namespace,
+			Subsystem: subsystem,
+			Name:      "handled_total",
+			Help:      "Total number of RPCs completed by the client, regardless of success or failure.",
+		}, codeLabels),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: c.namespace,
+			Subsystem: subsystem,
+			Name:      "in_flight",
+			Help:      "Number of RPCs in flight.",
+		}, labels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: c.namespace,
+			Subsystem: subsystem,
+			Name:      "handling_seconds",
+			Help:      "Duration of the RPCs until they are completed by the client.",
+			Buckets:   c.durationBuckets,
+		}, codeLabels),
+		requestSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: c.namespace,
+			Subsystem: subsystem,
+			Name:      "request_size_bytes",
+			Help:      "Total size of the messages sent per RPC.",
+			Buckets:   c.sizeBuckets,
+		}, codeLabels),
+		responseSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: c.namespace,
+			Subsystem: subsystem,
+			Name:      "response_size_bytes",
+			Help:      "Total size of the messages received per RPC.",
+			Buckets:   c.sizeBuckets,
+		}, codeLabels),
+		msgSent: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: c.namespace,
+			Subsystem: subsystem,
+			Name:      "msg_sent_total",
+			Help:      "Total number of messages sent by the client.",
+		}, codeLabels),
+		msgReceived: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: c.namespace,
+			Subsystem: subsystem,
+			Name:      "msg_received_total",
+			Help:      "Total number of messages received by the client.",
+		}, codeLabels),
+	}
+}
+
+func (m *Metrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.started,
+		m.handled,
+		m.inFlight,
+		m.duration,
+		m.requestSize,
+		m.responseSize,
+		m.msgSent,
+		m.msgReceived,
+	}
+}
+
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	for _, c := range m.collectors() {
+		c.Describe(ch)
+	}
+}
+
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	for _, c := range m.collectors() {
+		c.Collect(ch)
+	}
+}
+
+func (m *Metrics) CallStarted(_ context.Context, method string) {
+	service, rpc := splitMethod(method)
+	m.started.WithLabelValues(service, rpc).Inc()
+	m.inFlight.WithLabelValues(service, rpc).Inc()
+}
+
+func (m *Metrics) CallFinished(_ context.Context, stats gateway.CallStats) {
+	service, rpc := splitMethod(stats.Method)
+	code := stats.Code.String()
+	m.inFlight.WithLabelValues(service, rpc).Dec()
+	m.handled.WithLabelValues(service, rpc, code).Inc()
+	m.duration.WithLabelValues(service, rpc, code).Observe(stats.Duration.Seconds())
+	m.requestSize.WithLabelValues(service, rpc, code).Observe(float64(stats.RequestSize))
+	m.responseSize.WithLabelValues(service, rpc, code).Observe(float64(stats.ResponseSize))
+	m.msgSent.WithLabelValues(service, rpc, code).Add(float64(stats.SentMessages))
+	m.msgReceived.WithLabelValues(service, rpc, code).Add(float64(stats.ReceivedMessages))
+}
+
+func splitMethod(method string) (string, string) {
+	service, rpc, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	return service, rpc
+}
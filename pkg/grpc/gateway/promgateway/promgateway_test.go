@@ -0,0 +1,88 @@
+package promgateway_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/akuity/grpc-gateway-client/pkg/grpc/gateway"
+	"github.com/akuity/grpc-gateway-client/pkg/grpc/gateway/promgateway"
+)
+
+func TestMetrics(t *testing.T) {
+	m := promgateway.NewMetrics(
+		promgateway.WithNamespace("test"),
+		promgateway.WithDurationBuckets([]float64{1}),
+		promgateway.WithSizeBuckets([]float64{100}),
+	)
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(t, reg.Register(m))
+
+	method := "/io.akuity.test.v1.TestService/TrackInvitation"
+	m.CallStarted(context.TODO(), method)
+	m.CallStarted(context.TODO(), method)
+	m.CallFinished(context.TODO(), gateway.CallStats{
+		Method:           method,
+		Code:             codes.FailedPrecondition,
+		Duration:         500 * time.Millisecond,
+		Streaming:        true,
+		SentMessages:     1,
+		ReceivedMessages: 3,
+		RequestSize:      10,
+		ResponseSize:     42,
+	})
+
+	expected := `
+# HELP test_grpc_gateway_client_handled_total Total number of RPCs completed by the client, regardless of success or failure.
+# TYPE test_grpc_gateway_client_handled_total counter
+test_grpc_gateway_client_handled_total{grpc_code="FailedPrecondition",grpc_method="TrackInvitation",grpc_service="io.akuity.test.v1.TestService"} 1
+# HELP test_grpc_gateway_client_handling_seconds Duration of the RPCs until they are completed by the client.
+# TYPE test_grpc_gateway_client_handling_seconds histogram
+test_grpc_gateway_client_handling_seconds_bucket{grpc_code="FailedPrecondition",grpc_method="TrackInvitation",grpc_service="io.akuity.test.v1.TestService",le="1"} 1
+test_grpc_gateway_client_handling_seconds_bucket{grpc_code="FailedPrecondition",grpc_method="TrackInvitation",grpc_service="io.akuity.test.v1.TestService",le="+Inf"} 1
+test_grpc_gateway_client_handling_seconds_sum{grpc_code="FailedPrecondition",grpc_method="TrackInvitation",grpc_service="io.akuity.test.v1.TestService"} 0.5
+test_grpc_gateway_client_handling_seconds_count{grpc_code="FailedPrecondition",grpc_method="TrackInvitation",grpc_service="io.akuity.test.v1.TestService"} 1
+# HELP test_grpc_gateway_client_in_flight Number of RPCs in flight.
+# TYPE test_grpc_gateway_client_in_flight gauge
+test_grpc_gateway_client_in_flight{grpc_method="TrackInvitation",grpc_service="io.akuity.test.v1.TestService"} 1
+# HELP test_grpc_gateway_client_msg_received_total Total number of messages received by the client.
+# TYPE test_grpc_gateway_client_msg_received_total counter
+test_grpc_gateway_client_msg_received_total{grpc_code="FailedPrecondition",grpc_method="TrackInvitation",grpc_service="io.akuity.test.v1.TestService"} 3
+# HELP test_grpc_gateway_client_msg_sent_total Total number of messages sent by the client.
+# TYPE test_grpc_gateway_client_msg_sent_total counter
+test_grpc_gateway_client_msg_sent_total{grpc_code="FailedPrecondition",grpc_method="TrackInvitation",grpc_service="io.akuity.test.v1.TestService"} 1
+# HELP test_grpc_gateway_client_request_size_bytes Total size of the messages sent per RPC.
+# TYPE test_grpc_gateway_client_request_size_bytes histogram
+test_grpc_gateway_client_request_size_bytes_bucket{grpc_code="FailedPrecondition",grpc_method="TrackInvitation",grpc_service="io.akuity.test.v1.TestService",le="100"} 1
+test_grpc_gateway_client_request_size_bytes_bucket{grpc_code="FailedPrecondition",grpc_method="TrackInvitation",grpc_service="io.akuity.test.v1.TestService",le="+Inf"} 1
+test_grpc_gateway_client_request_size_bytes_sum{grpc_code="FailedPrecondition",grpc_method="TrackInvitation",grpc_service="io.akuity.test.v1.TestService"} 10
+test_grpc_gateway_client_request_size_bytes_count{grpc_code="FailedPrecondition",grpc_method="TrackInvitation",grpc_service="io.akuity.test.v1.TestService"} 1
+# HELP test_grpc_gateway_client_response_size_bytes Total size of the messages received per RPC.
+# TYPE test_grpc_gateway_client_response_size_bytes histogram
+test_grpc_gateway_client_response_size_bytes_bucket{grpc_code="FailedPrecondition",grpc_method="TrackInvitation",grpc_service="io.akuity.test.v1.TestService",le="100"} 1
+test_grpc_gateway_client_response_size_bytes_bucket{grpc_code="FailedPrecondition",grpc_method="TrackInvitation",grpc_service="io.akuity.test.v1.TestService",le="+Inf"} 1
+test_grpc_gateway_client_response_size_bytes_sum{grpc_code="FailedPrecondition",grpc_method="TrackInvitation",grpc_service="io.akuity.test.v1.TestService"} 42
+test_grpc_gateway_client_response_size_bytes_count{grpc_code="FailedPrecondition",grpc_method="TrackInvitation",grpc_service="io.akuity.test.v1.TestService"} 1
+# HELP test_grpc_gateway_client_started_total Total number of RPCs started by the client.
+# TYPE test_grpc_gateway_client_started_total counter
+test_grpc_gateway_client_started_total{grpc_method="TrackInvitation",grpc_service="io.akuity.test.v1.TestService"} 2
+`
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected)))
+}
+
+func TestMetrics_GRPCPrometheus(t *testing.T) {
+	// The metrics can be registered along with the client metrics of
+	// go-grpc-prometheus, whose labels differ.
+	reg := prometheus.NewRegistry()
+	require.NoError(t, reg.Register(prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_client_started_total",
+		Help: "Total number of RPCs started on the client.",
+	}, []string{"grpc_type", "grpc_service", "grpc_method"})))
+	require.NoError(t, reg.Register(promgateway.NewMetrics()))
+}
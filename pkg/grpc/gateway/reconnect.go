@@ -0,0 +1,234 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"google.golang.org/grpc/codes"
+
+	ctxutil "github.com/akuity/grpc-gateway-client/pkg/context"
+)
+
+const lastEventIDHeader = "Last-Event-ID"
+
+// ReconnectPolicy configures how streams of server-sent events are resumed
+// after a transport error.
+type ReconnectPolicy struct {
+	// MaxRetries is the number of consecutive reconnection attempts before
+	// the error is returned to the caller.
+	MaxRetries int
+	// InitialBackoff is the delay before the first reconnection attempt. The
+	// retry hint of the server takes precedence if it was sent.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between reconnection attempts.
+	MaxBackoff time.Duration
+	// BackoffMultiplier is the factor by which the delay grows after every
+	// failed attempt.
+	BackoffMultiplier float64
+}
+
+// DefaultReconnectPolicy is a reasonable policy for long-lived watches.
+var DefaultReconnectPolicy = ReconnectPolicy{
+	MaxRetries:        5,
+	InitialBackoff:    time.Second,
+	MaxBackoff:        30 * time.Second,
+	BackoffMultiplier: 2,
+}
+
+func (p ReconnectPolicy) backoff(initial time.Duration, attempt int) time.Duration {
+	return exponentialBackoff(initial, p.BackoffMultiplier, p.MaxBackoff, attempt)
+}
+
+type reconnectPolicyKey struct {
+	/* explicitly empty */
+}
+
+// SetReconnectPolicy enables the reconnection of the streams started by
+// DoStreamingRequest with the returned context. When a stream of server-sent
+// events fails, the request is sent again with the Last-Event-ID header, and
+// the events that were already delivered are skipped.
+func SetReconnectPolicy(ctx context.Context, p ReconnectPolicy) context.Context {
+	return ctxutil.Set(ctx, reconnectPolicyKey{}, p)
+}
+
+func getReconnectPolicy(ctx context.Context) (ReconnectPolicy, bool) {
+	return ctxutil.Get[reconnectPolicyKey, ReconnectPolicy](ctx, reconnectPolicyKey{})
+}
+
+// eventReader reads the messages of a streaming response, and reconnects
+// streams of server-sent events if the context carries a reconnect policy.
+type eventReader struct {
+	ctx context.Context
+	c   Client
+	req *resty.Request
+	url string
+
+	policy    ReconnectPolicy
+	reconnect bool
+
+	res      *http.Response
+	body     io.ReadCloser
+	dec      streamDecoder
+	failures int
+
+	retryHint   time.Duration
+	lastEventID string
+	// seen holds the ids of the recently delivered events, which are skipped
+	// when the server replays them after a reconnection. Event ids need not be
+	// unique, so only the events before the first new one are skipped.
+	seen      *eventIDWindow
+	replaying bool
+}
+
+// maxSeenEventIDs is the number of event ids remembered to skip the events
+// replayed after a reconnection, so that long-lived streams use bounded
+// memory.
+const maxSeenEventIDs = 256
+
+// eventIDWindow remembers the last event ids added to it.
+type eventIDWindow struct {
+	ids  []string
+	next int
+	set  map[string]struct{}
+}
+
+func newEventIDWindow(size int) *eventIDWindow {
+	return &eventIDWindow{
+		ids: make([]string, 0, size),
+		set: make(map[string]struct{}, size),
+	}
+}
+
+// has reports whether the id is remembered.
+func (w *eventIDWindow) has(id string) bool {
+	_, ok := w.set[id]
+	return ok
+}
+
+// add remembers the id, forgetting the oldest one if the window is full.
+func (w *eventIDWindow) add(id string) {
+	if w.has(id) {
+		return
+	}
+	if len(w.ids) < cap(w.ids) {
+		w.ids = append(w.ids, id)
+	} else {
+		delete(w.set, w.ids[w.next])
+		w.ids[w.next] = id
+		w.next = (w.next + 1) % len(w.ids)
+	}
+	w.set[id] = struct{}{}
+}
+
+func newEventReader(ctx context.Context, c Client, req *resty.Request, url string, res *resty.Response) *eventReader {
+	policy, reconnect := getReconnectPolicy(ctx)
+	r := &eventReader{
+		ctx:       ctx,
+		c:         c,
+		req:       req,
+		url:       url,
+		policy:    policy,
+		reconnect: reconnect,
+	}
+	if reconnect {
+		r.seen = newEventIDWindow(maxSeenEventIDs)
+	}
+	r.setResponse(res)
+	return r
+}
+
+func (r *eventReader) setResponse(res *resty.Response) {
+	r.res = res.RawResponse
+	r.body = newIdleTimeoutReader(res.RawBody(), getStreamIdleTimeout(r.c))
+	r.dec = newStreamDecoder(res.Header().Get("Content-Type"), r.body)
+}
+
+func (r *eventReader) next() (*streamEvent, error) {
+	for {
+		event, err := r.dec.Decode()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, err
+			}
+			if !r.resumable() {
+				return nil, newTransportError(r.ctx, "read stream", err)
+			}
+			if err := r.resume(err); err != nil {
+				return nil, newTransportError(r.ctx, "read stream", err)
+			}
+			continue
+		}
+
+		if r.replaying && event.hasID && r.seen.has(event.id) {
+			continue
+		}
+		r.replaying = false
+		if event.hasID {
+			r.lastEventID = event.id
+			if r.seen != nil {
+				r.seen.add(event.id)
+			}
+		}
+		r.failures = 0
+		return event, nil
+	}
+}
+
+func (r *eventReader) resumable() bool {
+	_, ok := r.dec.(*eventStreamDecoder)
+	return r.reconnect && ok && r.ctx.Err() == nil
+}
+
+// resume reconnects to the server after the stream failed with err. It returns
+// the last error once the retry budget is used up.
+func (r *eventReader) resume(err error) error {
+	_ = r.body.Close()
+	if retry, ok := r.dec.(*eventStreamDecoder).retryHint(); ok {
+		r.retryHint = retry
+	}
+	initial := r.policy.InitialBackoff
+	if r.retryHint > 0 {
+		initial = r.retryHint
+	}
+
+	for r.failures < r.policy.MaxRetries {
+		timer := time.NewTimer(r.policy.backoff(initial, r.failures))
+		select {
+		case <-r.ctx.Done():
+			timer.Stop()
+			return r.ctx.Err()
+		case <-timer.C:
+		}
+		r.failures++
+
+		// resty rewrites the URL with the path and query params on every send.
+		r.req.URL = r.url
+		if r.lastEventID != "" {
+			r.req.SetHeader(lastEventIDHeader, r.lastEventID)
+		}
+		res, sendErr := sendStreamingRequest(r.ctx, r.req)
+		if sendErr != nil {
+			err = sendErr
+			continue
+		}
+		if res.IsError() {
+			err = wrapStreamingResponseError(r.c, res)
+			if httpStatusCode(r.c, res.StatusCode()) == codes.Unavailable {
+				continue
+			}
+			return err
+		}
+		r.setResponse(res)
+		r.replaying = true
+		return nil
+	}
+	return err
+}
+
+func (r *eventReader) close() {
+	_ = r.body.Close()
+}
@@ -0,0 +1,23 @@
+package gateway
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventIDWindow(t *testing.T) {
+	w := newEventIDWindow(2)
+	w.add("1")
+	w.add("2")
+	w.add("1")
+	require.True(t, w.has("1"))
+	require.True(t, w.has("2"))
+
+	// The oldest ids are forgotten first.
+	w.add("3")
+	require.False(t, w.has("1"))
+	require.True(t, w.has("2"))
+	require.True(t, w.has("3"))
+	require.Len(t, w.set, 2)
+}
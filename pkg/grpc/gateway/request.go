@@ -1,19 +1,23 @@
 package gateway
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"mime"
+	"net/http"
+	"time"
 
-	"github.com/alevinval/sse/pkg/decoder"
 	"github.com/go-resty/resty/v2"
 	"google.golang.org/genproto/googleapis/api/httpbody"
 	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/encoding/protojson"
+
+	gwerrors "github.com/akuity/grpc-gateway-client/pkg/grpc/gateway/errors"
 )
 
 type streamingResponse map[string]json.RawMessage
@@ -21,44 +25,85 @@ type streamingResponse map[string]json.RawMessage
 const (
 	streamingResponseResultKey = "result"
 	streamingResponseErrorKey  = "error"
+
+	eventStreamContentType = "text/event-stream"
+	jsonContentType        = "application/json"
 )
 
+// DoRequest sends a request created by Client.NewRequest, and decodes the
+// response into T.
 func DoRequest[T any](ctx context.Context, req *resty.Request) (*T, error) {
-	var resBody T
-	if _, ok := any(&resBody).(*httpbody.HttpBody); ok {
-		res, err := doHTTPRequest(ctx, req)
-		if err != nil {
-			return nil, err
-		}
-		return res.(*T), nil
+	res, err := DoRequestWithResponse[T](ctx, getRequestClient(req), req)
+	if err != nil {
+		return nil, err
 	}
+	return res.Message, nil
+}
 
-	res, err := req.SetContext(ctx).
-		SetResult(&resBody).
-		SetError(&rpcstatus.Status{}).
-		Send()
+// DoRequestWithResponse is like DoRequest, but also returns the metadata and
+// the status of the response. The response is returned along with the error
+// when the server answered with an error.
+func DoRequestWithResponse[T any](ctx context.Context, c Client, req *resty.Request) (*Response[T], error) {
+	var resBody T
+	start := time.Now()
+	res, err := doUnaryRequest(ctx, c, req, &resBody)
+	if res == nil {
+		return nil, err
+	}
+	out := newResponse[T](res.RawResponse, time.Since(start))
 	if err != nil {
-		return nil, fmt.Errorf("send request: %w", err)
+		return out, err
 	}
+	out.Message = &resBody
+	return out, nil
+}
 
-	if res.IsError() {
-		errRes, ok := res.Error().(*rpcstatus.Status)
-		if !ok {
-			return nil, fmt.Errorf("cast error response: %s", res.String())
+// doUnaryRequest sends the request and decodes the response into reply. The
+// response is returned if the server answered, even with an error.
+func doUnaryRequest(ctx context.Context, c Client, req *resty.Request, reply any) (*resty.Response, error) {
+	body, isHTTPBody := reply.(*httpbody.HttpBody)
+	if !isHTTPBody {
+		req.SetResult(reply)
+	}
+
+	var res *resty.Response
+	err := retryRequest(ctx, c, req, func() error {
+		res = nil
+		r, err := req.SetContext(withClient(ctx, c)).
+			SetHeader("TE", "trailers").
+			Send()
+		if err != nil {
+			return newTransportError(ctx, "send request", err)
 		}
-		if err := status.ErrorProto(errRes); err != nil {
-			return nil, err
+		res = r
+		if res.IsError() {
+			return unmarshalErrorResponse(c, res.StatusCode(), res.Header(), res.Body())
 		}
-		return nil, status.Error(HTTPStatusToCode(res.StatusCode()), errRes.String())
+		return nil
+	})
+	if res == nil {
+		return nil, err
+	}
+	captureHeader(ctx, getResponseHeader(res.RawResponse))
+	captureStatusCode(ctx, res.StatusCode())
+	captureTrailer(ctx, getResponseTrailer(res.RawResponse))
+	if err != nil {
+		return res, err
 	}
 
-	data, ok := res.Result().(*T)
-	if !ok {
-		return nil, fmt.Errorf("cast response: %s", res.String())
+	if isHTTPBody {
+		body.ContentType = res.Header().Get("Content-Type")
+		body.Data = res.Body()
 	}
-	return data, nil
+	return res, nil
 }
 
+// DoStreamingRequest sends the request and delivers the server-streamed
+// responses on the returned channels. The response channel is closed when the
+// stream ends. The error channel then yields at most one error, and is closed
+// too, so a nil receive means that the stream finished successfully.
+// Canceling ctx stops the stream and releases the underlying connection, so
+// callers that stop reading early must cancel it.
 func DoStreamingRequest[T any](ctx context.Context, c Client, req *resty.Request) (<-chan *T, <-chan error, error) {
 	var resBody T
 	if _, ok := any(&resBody).(*httpbody.HttpBody); ok {
@@ -69,40 +114,60 @@ func DoStreamingRequest[T any](ctx context.Context, c Client, req *resty.Request
 		return resCh.(chan *T), errCh, nil
 	}
 
-	rawRes, err := req.SetContext(ctx).
-		SetHeader("Accept", "text/event-stream").
-		SetHeader("Cache-Control", "no-cache").
-		SetHeader("Connection", "keep-alive").
-		SetDoNotParseResponse(true).
-		Send()
-	if err != nil {
-		return nil, nil, fmt.Errorf("send request: %w", err)
+	ctx, cancel := withStreamMaxDuration(ctx, getStreamMaxDuration(c))
+	url := req.URL
+	var rawRes *resty.Response
+	err := retryRequest(ctx, c, req, func() error {
+		var err error
+		rawRes, err = sendStreamingRequest(ctx, req)
+		if err != nil {
+			return err
+		}
+		if rawRes.IsError() {
+			return wrapStreamingResponseError(c, rawRes)
+		}
+		return nil
+	})
+	if rawRes != nil {
+		captureHeader(ctx, getResponseHeader(rawRes.RawResponse))
+		captureStatusCode(ctx, rawRes.StatusCode())
 	}
-	if rawRes.IsError() {
-		return nil, nil, wrapStreamingResponseError(c, rawRes)
+	if err != nil {
+		cancel()
+		return nil, nil, err
 	}
 
-	resCh := make(chan *T)
-	errCh := make(chan error)
-
+	stream := newResponseStream[T](ctx)
+	events := newEventReader(ctx, c, req, url, rawRes)
+	stream.onClose = func() {
+		captureTrailer(ctx, getResponseTrailer(events.res))
+	}
 	go func() {
-		body := rawRes.RawBody()
-		defer func() { _ = body.Close() }()
-		eventDecoder := decoder.New(body)
+		defer cancel()
+		defer events.close()
 		for {
-			event, err := eventDecoder.Decode()
+			event, err := events.next()
 			if err != nil {
 				if errors.Is(err, io.EOF) {
-					close(resCh)
+					stream.close(nil)
 					return
 				}
-				errCh <- err
+				stream.close(err)
 				return
 			}
 
 			var res streamingResponse
-			if err := json.Unmarshal([]byte(event.GetData()), &res); err != nil {
-				errCh <- fmt.Errorf("unmarshal streaming response: %w", err)
+			if err := json.Unmarshal(event.data, &res); err != nil {
+				stream.close(fmt.Errorf("unmarshal streaming response: %w", err))
+				return
+			}
+			if rawErrRes, ok := res[streamingResponseErrorKey]; ok {
+				stream.close(unmarshalStreamingError(c, rawErrRes))
+				return
+			}
+			if event.name == eventStreamErrorEvent {
+				// The error event may carry the status itself.
+				stream.close(unmarshalStreamingError(c, event.data))
 				return
 			}
 			rawResult, ok := res[streamingResponseResultKey]
@@ -112,70 +177,215 @@ func DoStreamingRequest[T any](ctx context.Context, c Client, req *resty.Request
 
 			var data T
 			if err := c.Unmarshal(rawResult, &data); err != nil {
-				errCh <- err
+				stream.close(err)
+				return
+			}
+			if !stream.send(&data) {
 				return
 			}
-			resCh <- &data
 		}
 	}()
-	return resCh, errCh, nil
+	return stream.resCh, stream.errCh, nil
 }
 
-func doHTTPRequest(ctx context.Context, req *resty.Request) (any, error) {
-	res, err := req.SetContext(ctx).
-		SetError(&rpcstatus.Status{}).
-		Send()
-	if err != nil {
-		return nil, fmt.Errorf("send request: %w", err)
-	}
-	if res.IsError() {
-		errRes, ok := res.Error().(*rpcstatus.Status)
-		if !ok {
-			return nil, fmt.Errorf("cast error response: %s", res.String())
+func sendStreamingRequest(ctx context.Context, req *resty.Request) (*resty.Response, error) {
+	// Prefer server-sent events, but accept the newline-delimited JSON that
+	// grpc-gateway streams by default.
+	req.SetHeader("Accept", eventStreamContentType)
+	req.Header.Add("Accept", jsonContentType)
+	return doRawRequest(ctx, req)
+}
+
+// HTTPBodyReader is the body of a streamed google.api.HttpBody response.
+type HTTPBodyReader struct {
+	io.ReadCloser
+
+	ContentType string
+
+	res *http.Response
+}
+
+// Trailer returns the trailer metadata of the response, once the body has
+// been read to the end.
+func (r *HTTPBodyReader) Trailer() metadata.MD {
+	return getResponseTrailer(r.res)
+}
+
+// DoHTTPBodyStreamingRequest sends the request and returns the response body
+// as it arrives, without buffering it. Reads of the body fail once they wait
+// longer than the stream idle timeout of the client. The caller must close
+// the body.
+func DoHTTPBodyStreamingRequest(ctx context.Context, c Client, req *resty.Request) (*HTTPBodyReader, error) {
+	var res *resty.Response
+	err := retryRequest(ctx, c, req, func() error {
+		var err error
+		res, err = doRawRequest(ctx, req)
+		if err != nil {
+			return err
 		}
-		if err := status.ErrorProto(errRes); err != nil {
-			return nil, err
+		if res.IsError() {
+			return wrapStreamingResponseError(c, res)
 		}
-		return nil, status.Error(HTTPStatusToCode(res.StatusCode()), errRes.String())
+		return nil
+	})
+	if res != nil {
+		captureHeader(ctx, getResponseHeader(res.RawResponse))
+		captureStatusCode(ctx, res.StatusCode())
+	}
+	if err != nil {
+		return nil, err
 	}
-	return &httpbody.HttpBody{
+	return &HTTPBodyReader{
+		ReadCloser:  newIdleTimeoutReader(res.RawBody(), getStreamIdleTimeout(c)),
 		ContentType: res.Header().Get("Content-Type"),
-		Data:        res.Body(),
+		res:         res.RawResponse,
 	}, nil
 }
 
-func doHTTPStreamingRequest(ctx context.Context, c Client, req *resty.Request) (any, <-chan error, error) {
+// doRawRequest sends the request without parsing the response body, which is
+// left for the caller to read and close.
+func doRawRequest(ctx context.Context, req *resty.Request) (*resty.Response, error) {
 	res, err := req.SetContext(ctx).
 		SetHeader("Cache-Control", "no-cache").
 		SetHeader("Connection", "keep-alive").
+		SetHeader("TE", "trailers").
 		SetDoNotParseResponse(true).
 		Send()
 	if err != nil {
-		return nil, nil, fmt.Errorf("send request: %w", err)
+		return nil, newTransportError(ctx, "send request", err)
 	}
-	if res.IsError() {
-		return nil, nil, wrapStreamingResponseError(c, res)
+	return res, nil
+}
+
+func doHTTPStreamingRequest(ctx context.Context, c Client, req *resty.Request) (any, <-chan error, error) {
+	ctx, cancel := withStreamMaxDuration(ctx, getStreamMaxDuration(c))
+	body, err := DoHTTPBodyStreamingRequest(ctx, c, req)
+	if err != nil {
+		cancel()
+		return nil, nil, err
 	}
 
-	resCh := make(chan *httpbody.HttpBody)
-	errCh := make(chan error)
+	stream := newResponseStream[httpbody.HttpBody](ctx)
+	stream.onClose = func() {
+		captureTrailer(ctx, body.Trailer())
+	}
 	go func() {
-		contentType := res.Header().Get("Content-Type")
-		body := res.RawBody()
+		defer cancel()
 		defer func() { _ = body.Close() }()
 
-		var data bytes.Buffer
-		if _, err := io.Copy(&data, body); err != nil {
-			errCh <- fmt.Errorf("copy body: %w", err)
-			return
-		}
-		resCh <- &httpbody.HttpBody{
-			ContentType: contentType,
-			Data:        data.Bytes(),
+		buf := make([]byte, getHTTPBodyChunkSize(c))
+		for {
+			n, err := body.Read(buf)
+			if n > 0 {
+				data := make([]byte, n)
+				copy(data, buf[:n])
+				if !stream.send(&httpbody.HttpBody{
+					ContentType: body.ContentType,
+					Data:        data,
+				}) {
+					return
+				}
+			}
+			if errors.Is(err, io.EOF) {
+				stream.close(nil)
+				return
+			}
+			if err != nil {
+				stream.close(newTransportError(ctx, "read body", err))
+				return
+			}
 		}
-		close(resCh)
 	}()
-	return resCh, errCh, nil
+	return stream.resCh, stream.errCh, nil
+}
+
+// streamEvent is a message of a streaming response. Only server-sent events
+// carry an id and a name.
+type streamEvent struct {
+	id    string
+	hasID bool
+	name  string
+	data  []byte
+}
+
+type streamDecoder interface {
+	Decode() (*streamEvent, error)
+}
+
+// newStreamDecoder returns a decoder that reads server-sent events or
+// newline-delimited JSON from the body, depending on the content type.
+func newStreamDecoder(contentType string, body io.Reader) streamDecoder {
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType == eventStreamContentType {
+		return newEventStreamDecoder(body)
+	}
+	return &jsonStreamDecoder{
+		d: json.NewDecoder(body),
+	}
+}
+
+type jsonStreamDecoder struct {
+	d *json.Decoder
+}
+
+func (d *jsonStreamDecoder) Decode() (*streamEvent, error) {
+	var msg json.RawMessage
+	if err := d.d.Decode(&msg); err != nil {
+		return nil, err
+	}
+	return &streamEvent{
+		data: msg,
+	}, nil
+}
+
+// responseStream is the producer side of the channels returned by
+// DoStreamingRequest.
+type responseStream[T any] struct {
+	ctx   context.Context
+	resCh chan *T
+	errCh chan error
+	// onClose is called before the channels are closed.
+	onClose func()
+}
+
+func newResponseStream[T any](ctx context.Context) *responseStream[T] {
+	return &responseStream[T]{
+		ctx:   ctx,
+		resCh: make(chan *T),
+		// The error channel is buffered, so that the final error never blocks
+		// the producer.
+		errCh: make(chan error, 1),
+	}
+}
+
+// send delivers the response, unless the context is done first, in which case
+// the stream is closed with the context error and false is returned.
+func (s *responseStream[T]) send(res *T) bool {
+	select {
+	case s.resCh <- res:
+		return true
+	case <-s.ctx.Done():
+		s.close(nil)
+		return false
+	}
+}
+
+// close ends the stream with the given error, if any. Errors caused by the
+// context are reported as the corresponding gRPC status.
+func (s *responseStream[T]) close(err error) {
+	if ctxErr := s.ctx.Err(); ctxErr != nil {
+		err = status.FromContextError(ctxErr).Err()
+		if cause := context.Cause(s.ctx); errors.Is(cause, errStreamMaxDuration) {
+			err = cause
+		}
+	}
+	if err != nil {
+		s.errCh <- err
+	}
+	if s.onClose != nil {
+		s.onClose()
+	}
+	close(s.resCh)
+	close(s.errCh)
 }
 
 func wrapStreamingResponseError(c Client, resp *resty.Response) error {
@@ -185,25 +395,37 @@ func wrapStreamingResponseError(c Client, resp *resty.Response) error {
 	if err != nil {
 		return fmt.Errorf("read error response body: %w", err)
 	}
+	return unmarshalErrorResponse(c, resp.StatusCode(), resp.Header(), data)
+}
 
-	var streamingResp streamingResponse
-	if err := json.Unmarshal(data, &streamingResp); err != nil {
-		return fmt.Errorf("unmarshal raw response: %w", err)
-	}
-	rawErrRes, ok := streamingResp[streamingResponseErrorKey]
-	if !ok {
-		var statusResp rpcstatus.Status
-		if err := protojson.Unmarshal(data, &statusResp); err != nil {
-			return errors.New(string(data))
-		}
-		return status.FromProto(&statusResp).Err()
-	}
-	var errResp rpcstatus.Status
-	if err := c.Unmarshal(rawErrRes, &errResp); err != nil {
+// unmarshalStreamingError decodes the status of an error frame sent by the
+// gateway in the middle of a stream.
+func unmarshalStreamingError(c Client, rawErrRes json.RawMessage) error {
+	var errRes rpcstatus.Status
+	if err := c.Unmarshal(rawErrRes, &errRes); err != nil {
 		return fmt.Errorf("unmarshal error response: %w", err)
 	}
-	if err := status.ErrorProto(&errResp); err != nil {
-		return err
+	st := status.FromProto(&errRes)
+	if st.Code() == codes.OK {
+		st = status.New(codes.Unknown, string(rawErrRes))
+	}
+	return gwerrors.New(st, 0, nil)
+}
+
+// unmarshalErrorResponse returns the error of a response with the given
+// body. Bodies without a gRPC status, such as the error pages of proxies, are
+// returned as HTTPError.
+func unmarshalErrorResponse(c Client, statusCode int, header http.Header, data []byte) error {
+	var streamingResp streamingResponse
+	if err := json.Unmarshal(data, &streamingResp); err == nil {
+		rawErrRes, ok := streamingResp[streamingResponseErrorKey]
+		if !ok {
+			rawErrRes = data
+		}
+		var errRes rpcstatus.Status
+		if err := c.Unmarshal(rawErrRes, &errRes); err == nil && errRes.GetCode() != int32(codes.OK) {
+			return gwerrors.New(status.FromProto(&errRes), statusCode, header)
+		}
 	}
-	return status.Error(HTTPStatusToCode(resp.StatusCode()), resp.String())
+	return newHTTPError(statusCode, httpStatusCode(c, statusCode), header, data)
 }
@@ -3,6 +3,9 @@ package gateway_test
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
+	"errors"
+	"io"
 	"net"
 	"net/http"
 	"net/http/httptest"
@@ -11,6 +14,7 @@ import (
 	"time"
 
 	"github.com/bufbuild/protoyaml-go"
+	"github.com/go-resty/resty/v2"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -18,14 +22,19 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoregistry"
 
 	"github.com/akuity/grpc-gateway-client/internal/assets"
 	"github.com/akuity/grpc-gateway-client/internal/test/gen/testv1"
 	"github.com/akuity/grpc-gateway-client/internal/test/server"
+	"github.com/akuity/grpc-gateway-client/internal/test/wsproxy"
 	"github.com/akuity/grpc-gateway-client/pkg/grpc/gateway"
+	gwerrors "github.com/akuity/grpc-gateway-client/pkg/grpc/gateway/errors"
 
 	_ "embed"
 )
@@ -35,6 +44,7 @@ type RequestTestSuite struct {
 
 	l       *bufconn.Listener
 	grpcSrv *grpc.Server
+	cc      *grpc.ClientConn
 	gwSrv   *httptest.Server
 	client  gateway.Client
 }
@@ -54,15 +64,17 @@ func (s *RequestTestSuite) SetupTest() {
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 	)
 	s.Require().NoError(err)
+	s.cc = cc
 
 	marshaller := &runtime.JSONPb{}
-	sseMarshaller := gateway.NewEventStreamMarshaller(marshaller)
+	sseMarshaller := gateway.NewEventStreamMarshaller(marshaller, gateway.WithEventIDSequence())
 	mux := runtime.NewServeMux(
 		runtime.WithMarshalerOption("application/json", marshaller),
 		runtime.WithMarshalerOption("text/event-stream", sseMarshaller),
+		runtime.WithMarshalerOption("application/octet-stream", server.NewHTTPBodyMarshaller(marshaller)),
 	)
 	s.Require().NoError(testv1.RegisterTestServiceHandler(context.TODO(), mux, cc))
-	s.gwSrv = httptest.NewServer(mux)
+	s.gwSrv = httptest.NewServer(wsproxy.New(gateway.KeepAliveEventStreams(mux, 50*time.Millisecond)))
 	s.client = gateway.NewClient(s.gwSrv.URL)
 }
 
@@ -76,6 +88,176 @@ func (s *RequestTestSuite) TestDoRequest() {
 	s.Require().NotEmpty(res.GetId())
 }
 
+func (s *RequestTestSuite) TestDoRequest_ReusedRequest() {
+	// The request keeps the settings of its client after it was sent, even
+	// though its context is replaced.
+	client := gateway.NewClient(s.gwSrv.URL, gateway.WithMarshaller(&runtime.JSONPb{
+		UnmarshalOptions: protojson.UnmarshalOptions{
+			Resolver: &protoregistry.Types{},
+		},
+	}))
+	req := client.NewRequest(http.MethodPost, "/invitation").
+		SetBody(&testv1.SendInvitationRequest{})
+	for i := 0; i < 2; i++ {
+		_, err := gateway.DoRequest[testv1.SendInvitationResponse](context.TODO(), req)
+		var badRequest *gwerrors.BadRequestError
+		s.Require().True(errors.As(err, &badRequest))
+	}
+}
+
+func (s *RequestTestSuite) TestDoRequest_RestyRequest() {
+	// Requests that were not created by a client are sent with the defaults.
+	req := resty.New().SetBaseURL(s.gwSrv.URL).R().
+		SetBody(`{"email":"test@test.com"}`)
+	req.Method = http.MethodPost
+	req.URL = "/invitation"
+	res, err := gateway.DoRequest[testv1.SendInvitationResponse](context.TODO(), req)
+	s.Require().NoError(err)
+	s.Require().NotEmpty(res.GetId())
+}
+
+func (s *RequestTestSuite) TestDoRequestWithResponse() {
+	req := s.client.NewRequest(http.MethodPost, "/invitation").
+		SetBody(&testv1.SendInvitationRequest{
+			Email: "test@test.com",
+		})
+	res, err := gateway.DoRequestWithResponse[testv1.SendInvitationResponse](context.TODO(), s.client, req)
+	s.Require().NoError(err)
+	s.Require().NotEmpty(res.Message.GetId())
+	s.Require().Equal([]string{"test"}, res.Header.Get("invitation-server"))
+	// grpc-gateway declares the trailers twice, so they are sent twice.
+	s.Require().Contains(res.Trailer.Get("invitation-status"), "done")
+	s.Require().Equal(http.StatusOK, res.StatusCode)
+	s.Require().Positive(res.Duration)
+}
+
+func (s *RequestTestSuite) TestDoRequestWithResponse_Error() {
+	req := s.client.NewRequest(http.MethodPost, "/invitation").
+		SetBody(&testv1.SendInvitationRequest{})
+	res, err := gateway.DoRequestWithResponse[testv1.SendInvitationResponse](context.TODO(), s.client, req)
+	s.Require().Equal(codes.InvalidArgument, status.Code(err))
+	s.Require().Nil(res.Message)
+	s.Require().Equal([]string{"test"}, res.Header.Get("invitation-server"))
+	// grpc-gateway declares the trailers twice, so they are sent twice.
+	s.Require().Contains(res.Trailer.Get("invitation-status"), "done")
+	s.Require().Equal(http.StatusBadRequest, res.StatusCode)
+}
+
+func (s *RequestTestSuite) TestDoRequest_CaptureMetadata() {
+	var header, trailer metadata.MD
+	ctx := gateway.CaptureHeader(context.TODO(), &header)
+	ctx = gateway.CaptureTrailer(ctx, &trailer)
+	req := s.client.NewRequest(http.MethodPost, "/invitation").
+		SetBody(&testv1.SendInvitationRequest{
+			Email: "test@test.com",
+		})
+	_, err := gateway.DoRequest[testv1.SendInvitationResponse](ctx, req)
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"test"}, header.Get("invitation-server"))
+	s.Require().Contains(trailer.Get("invitation-status"), "done")
+}
+
+func (s *RequestTestSuite) TestDoRequest_CaptureStatusCode() {
+	// Every caller that captures the status code gets it.
+	var outer, inner int
+	ctx := gateway.CaptureStatusCode(context.TODO(), &outer)
+	ctx = gateway.CaptureStatusCode(ctx, &inner)
+	req := s.client.NewRequest(http.MethodPost, "/invitation").
+		SetBody(&testv1.SendInvitationRequest{})
+	_, err := gateway.DoRequest[testv1.SendInvitationResponse](ctx, req)
+	s.Require().Error(err)
+	s.Require().Equal(http.StatusBadRequest, outer)
+	s.Require().Equal(http.StatusBadRequest, inner)
+}
+
+func (s *RequestTestSuite) TestDoRequest_ErrorDetails() {
+	// The error details are resolved even if the resolver of the marshaller
+	// does not know them.
+	client := gateway.NewClient(s.gwSrv.URL, gateway.WithMarshaller(&runtime.JSONPb{
+		UnmarshalOptions: protojson.UnmarshalOptions{
+			Resolver: &protoregistry.Types{},
+		},
+	}))
+	req := client.NewRequest(http.MethodPost, "/invitation").
+		SetBody(&testv1.SendInvitationRequest{})
+	_, err := gateway.DoRequest[testv1.SendInvitationResponse](context.TODO(), req)
+	s.Require().Equal(codes.InvalidArgument, status.Code(err))
+
+	var badRequest *gwerrors.BadRequestError
+	s.Require().True(errors.As(err, &badRequest))
+	s.Require().Equal("email", badRequest.Detail.GetFieldViolations()[0].GetField())
+	header, ok := gwerrors.Header(err)
+	s.Require().True(ok)
+	s.Require().Equal("application/json", header.Get("Content-Type"))
+}
+
+func (s *RequestTestSuite) TestDoRequest_HTTPStatusToCode() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	}))
+	defer srv.Close()
+
+	testSets := map[string]struct {
+		opts     []gateway.ClientOption
+		expected codes.Code
+	}{
+		"default mapping": {
+			expected: codes.AlreadyExists,
+		},
+		"custom mapping": {
+			opts: []gateway.ClientOption{
+				gateway.WithHTTPStatusToCode(func(status int) codes.Code {
+					if status == http.StatusConflict {
+						return codes.Aborted
+					}
+					return gateway.HTTPStatusToCode(status)
+				}),
+			},
+			expected: codes.Aborted,
+		},
+	}
+	for name, ts := range testSets {
+		s.Run(name, func() {
+			client := gateway.NewClient(srv.URL, ts.opts...)
+			_, err := gateway.DoRequest[testv1.SendInvitationResponse](context.TODO(), client.NewRequest(http.MethodPost, "/invitation"))
+			s.Require().Equal(ts.expected, status.Code(err))
+		})
+	}
+}
+
+func (s *RequestTestSuite) TestDoRequest_EventStreamBody() {
+	req := s.client.NewRequest(http.MethodPost, "/invitation").
+		SetHeader("Content-Type", "text/event-stream").
+		SetHeader("Accept", "application/json").
+		SetBody("data: {\"email\":\"test@test.com\"}")
+	res, err := gateway.DoRequest[testv1.SendInvitationResponse](context.TODO(), req)
+	s.Require().NoError(err)
+	s.Require().Equal(base64.StdEncoding.EncodeToString([]byte("test@test.com")), res.GetId())
+}
+
+func (s *RequestTestSuite) TestDoRequest_HTTPBody() {
+	req := gateway.SetHTTPBody(s.client.NewRequest(http.MethodPost, "/upload-invitations"), &httpbody.HttpBody{
+		ContentType: "application/octet-stream",
+		Data:        []byte(assets.LargeFile),
+	})
+	res, err := gateway.DoRequest[testv1.UploadInvitationsResponse](context.TODO(), req)
+	s.Require().NoError(err)
+	s.Require().Equal("application/octet-stream", res.GetContentType())
+	s.Require().Equal(int64(len(assets.LargeFile)), res.GetSize())
+}
+
+func (s *RequestTestSuite) TestDoRequest_HTTPBodyReader() {
+	req := gateway.SetHTTPBodyReader(
+		s.client.NewRequest(http.MethodPost, "/upload-invitations"),
+		"", // use default content type
+		strings.NewReader(assets.LargeFile),
+	)
+	res, err := gateway.DoRequest[testv1.UploadInvitationsResponse](context.TODO(), req)
+	s.Require().NoError(err)
+	s.Require().Equal("application/octet-stream", res.GetContentType())
+	s.Require().Equal(int64(len(assets.LargeFile)), res.GetSize())
+}
+
 func (s *RequestTestSuite) TestDoStreamingRequest() {
 	ctx, cancel := context.WithTimeout(context.TODO(), 300*time.Millisecond)
 	defer cancel()
@@ -96,6 +278,150 @@ func (s *RequestTestSuite) TestDoStreamingRequest() {
 	}
 }
 
+func (s *RequestTestSuite) TestDoStreamingRequest_CaptureHeader() {
+	var header metadata.MD
+	ctx := gateway.CaptureHeader(context.TODO(), &header)
+	req := s.client.NewRequest(http.MethodGet, "/invitation/some-id")
+	resCh, errCh, err := gateway.DoStreamingRequest[testv1.TrackInvitationResponse](ctx, s.client, req)
+	s.Require().NoError(err)
+	// The header is available as soon as the stream starts.
+	s.Require().Equal([]string{"test"}, header.Get("invitation-server"))
+	for range resCh {
+	}
+	s.Require().NoError(<-errCh)
+}
+
+func (s *RequestTestSuite) TestDoStreamingRequest_NewlineDelimitedJSON() {
+	ctx, cancel := context.WithTimeout(context.TODO(), 300*time.Millisecond)
+	defer cancel()
+
+	// A stock mux streams newline-delimited JSON instead of server-sent events.
+	mux := runtime.NewServeMux()
+	s.Require().NoError(testv1.RegisterTestServiceHandler(context.TODO(), mux, s.cc))
+	gwSrv := httptest.NewServer(mux)
+	defer gwSrv.Close()
+	client := gateway.NewClient(gwSrv.URL)
+
+	req := client.NewRequest(http.MethodGet, "/invitation/some-id")
+	resCh, _, err := gateway.DoStreamingRequest[testv1.TrackInvitationResponse](ctx, client, req)
+	s.Require().NoError(err)
+	select {
+	case <-ctx.Done():
+		s.Require().NoError(ctx.Err())
+	case data, ok := <-resCh:
+		s.Require().True(ok)
+		s.Require().True(testv1.EventType_EVENT_TYPE_UNDEFINED != data.GetType())
+	}
+}
+
+func (s *RequestTestSuite) TestDoStreamingRequest_Error() {
+	// A stock mux streams newline-delimited JSON instead of server-sent events.
+	mux := runtime.NewServeMux()
+	s.Require().NoError(testv1.RegisterTestServiceHandler(context.TODO(), mux, s.cc))
+	ndjsonSrv := httptest.NewServer(mux)
+	defer ndjsonSrv.Close()
+
+	for _, client := range []gateway.Client{s.client, gateway.NewClient(ndjsonSrv.URL)} {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		req := client.NewRequest(http.MethodGet, "/invitation/"+server.ExpiredInvitationID)
+		resCh, errCh, err := gateway.DoStreamingRequest[testv1.TrackInvitationResponse](ctx, client, req)
+		s.Require().NoError(err)
+
+		var events []testv1.EventType
+		for data := range resCh {
+			events = append(events, data.GetType())
+		}
+		s.Require().Equal([]testv1.EventType{testv1.EventType_EVENT_TYPE_SEEN}, events)
+		err = <-errCh
+		s.Require().Equal(codes.FailedPrecondition, status.Code(err))
+		s.Require().Equal("invitation expired", status.Convert(err).Message())
+		cancel()
+	}
+}
+
+func (s *RequestTestSuite) TestDoBidiStreamingRequest() {
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+
+	req := s.client.NewRequest(http.MethodPost, "/invitation/discuss")
+	stream, err := gateway.DoBidiStreamingRequest[testv1.DiscussInvitationRequest, testv1.DiscussInvitationResponse](ctx, s.client, req)
+	s.Require().NoError(err)
+
+	messages := []string{"hello", "world"}
+	for _, msg := range messages {
+		s.Require().NoError(stream.Send(&testv1.DiscussInvitationRequest{
+			Message: msg,
+		}))
+		res, err := stream.Recv()
+		s.Require().NoError(err)
+		s.Require().Equal(msg, res.GetMessage())
+	}
+	s.Require().NoError(stream.CloseSend())
+	_, err = stream.Recv()
+	s.Require().ErrorIs(err, io.EOF)
+}
+
+func (s *RequestTestSuite) TestDoBidiStreamingRequest_CloseSend() {
+	// The responses in flight are received after the sending direction is
+	// closed, even though the proxy handles close frames as usual.
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+
+	req := s.client.NewRequest(http.MethodPost, "/invitation/discuss")
+	stream, err := gateway.DoBidiStreamingRequest[testv1.DiscussInvitationRequest, testv1.DiscussInvitationResponse](ctx, s.client, req)
+	s.Require().NoError(err)
+
+	messages := []string{"hello", "world"}
+	for _, msg := range messages {
+		s.Require().NoError(stream.Send(&testv1.DiscussInvitationRequest{
+			Message: msg,
+		}))
+	}
+	s.Require().NoError(stream.CloseSend())
+	s.Require().Equal(codes.Internal, status.Code(stream.Send(&testv1.DiscussInvitationRequest{
+		Message: "late",
+	})))
+
+	var received []string
+	for {
+		res, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		s.Require().NoError(err)
+		received = append(received, res.GetMessage())
+	}
+	s.Require().Equal(messages, received)
+}
+
+func (s *RequestTestSuite) TestDoBidiStreamingRequest_Error() {
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+
+	req := s.client.NewRequest(http.MethodPost, "/invitation/discuss")
+	stream, err := gateway.DoBidiStreamingRequest[testv1.DiscussInvitationRequest, testv1.DiscussInvitationResponse](ctx, s.client, req)
+	s.Require().NoError(err)
+
+	s.Require().NoError(stream.Send(&testv1.DiscussInvitationRequest{}))
+	_, err = stream.Recv()
+	s.Require().Error(err)
+	stat, ok := status.FromError(err)
+	s.Require().True(ok)
+	s.Require().Equal(codes.InvalidArgument, stat.Code())
+}
+
+func (s *RequestTestSuite) TestDoBidiStreamingRequest_Cancel() {
+	ctx, cancel := context.WithCancel(context.TODO())
+
+	req := s.client.NewRequest(http.MethodPost, "/invitation/discuss")
+	stream, err := gateway.DoBidiStreamingRequest[testv1.DiscussInvitationRequest, testv1.DiscussInvitationResponse](ctx, s.client, req)
+	s.Require().NoError(err)
+
+	cancel()
+	_, err = stream.Recv()
+	s.Require().Equal(codes.Canceled, status.Code(err))
+}
+
 func (s *RequestTestSuite) TestDownloadRequest() {
 	ctx, cancel := context.WithTimeout(context.TODO(), 500*time.Millisecond)
 	defer cancel()
@@ -172,6 +498,74 @@ read:
 	require.Equal(s.T(), strings.TrimSpace(assets.LargeFile), strings.TrimSpace(buf.String()))
 }
 
+func (s *RequestTestSuite) TestDownloadRequest_Incremental() {
+	ctx, cancel := context.WithTimeout(context.TODO(), 500*time.Millisecond)
+	defer cancel()
+	req := s.client.NewRequest(http.MethodGet, "/download-invitations")
+	resCh, _, err := gateway.DoStreamingRequest[httpbody.HttpBody](ctx, s.client, req)
+	s.Require().NoError(err)
+
+	// The server sends the invitations one by one, so the first chunk must
+	// arrive before the whole body is written.
+	select {
+	case <-ctx.Done():
+		s.Require().NoError(ctx.Err())
+	case data := <-resCh:
+		invitation := &testv1.Invitation{}
+		doc := strings.TrimPrefix(string(data.GetData()), "---\n")
+		s.Require().NoError(protoyaml.Unmarshal([]byte(doc), invitation))
+		s.Require().Equal("test-1", invitation.GetId())
+	}
+}
+
+func (s *RequestTestSuite) TestDownloadLargeFileRequest_ChunkSize() {
+	ctx, cancel := context.WithTimeout(context.TODO(), 500*time.Millisecond)
+	defer cancel()
+	chunkSize := 1024
+	client := gateway.NewClient(s.gwSrv.URL, gateway.WithHTTPBodyChunkSize(chunkSize))
+	req := client.NewRequest(http.MethodGet, "/download-large-file")
+	resCh, errCh, err := gateway.DoStreamingRequest[httpbody.HttpBody](ctx, client, req)
+	s.Require().NoError(err)
+	var buf bytes.Buffer
+	chunks := 0
+
+read:
+	for {
+		select {
+		case <-ctx.Done():
+			break read
+		case err := <-errCh:
+			s.Require().NoError(err)
+		case data, ok := <-resCh:
+			if !ok {
+				break read
+			}
+			s.Require().LessOrEqual(len(data.GetData()), chunkSize)
+			s.Require().Equal("text/plain", data.GetContentType())
+			buf.Write(data.GetData())
+			chunks++
+		}
+	}
+
+	s.Require().NoError(ctx.Err())
+	s.Require().Greater(chunks, 1)
+	s.Require().Equal(strings.TrimSpace(assets.LargeFile), strings.TrimSpace(buf.String()))
+}
+
+func (s *RequestTestSuite) TestDoHTTPBodyStreamingRequest() {
+	ctx, cancel := context.WithTimeout(context.TODO(), 500*time.Millisecond)
+	defer cancel()
+	req := s.client.NewRequest(http.MethodGet, "/download-large-file")
+	body, err := gateway.DoHTTPBodyStreamingRequest(ctx, s.client, req)
+	s.Require().NoError(err)
+	defer func() { _ = body.Close() }()
+
+	data, err := io.ReadAll(body)
+	s.Require().NoError(err)
+	s.Require().Equal("text/plain", body.ContentType)
+	s.Require().Equal(strings.TrimSpace(assets.LargeFile), strings.TrimSpace(string(data)))
+}
+
 func (s *RequestTestSuite) TestDownloadRequest_Error() {
 	ctx, cancel := context.WithTimeout(context.TODO(), 500*time.Millisecond)
 	defer cancel()
@@ -0,0 +1,132 @@
+package gateway
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/metadata"
+
+	ctxutil "github.com/akuity/grpc-gateway-client/pkg/context"
+)
+
+// Response is the response of a unary request sent by DoRequestWithResponse.
+type Response[T any] struct {
+	// Message is nil if the server answered with an error.
+	Message *T
+	// Header is the metadata sent by the server as Grpc-Metadata- headers.
+	Header metadata.MD
+	// Trailer is the metadata sent by the server as Grpc-Trailer- trailers.
+	Trailer metadata.MD
+	// StatusCode is the status code of the HTTP response.
+	StatusCode int
+	// Duration is the time taken by the request, retries included.
+	Duration time.Duration
+}
+
+func newResponse[T any](res *http.Response, duration time.Duration) *Response[T] {
+	return &Response[T]{
+		Header:     getResponseHeader(res),
+		Trailer:    getResponseTrailer(res),
+		StatusCode: res.StatusCode,
+		Duration:   duration,
+	}
+}
+
+type headerKey struct {
+	/* explicitly empty */
+}
+
+type trailerKey struct {
+	/* explicitly empty */
+}
+
+type statusCodeKey struct {
+	/* explicitly empty */
+}
+
+// CaptureHeader stores the header metadata of the response in md, like
+// grpc.Header. Streams store it before DoStreamingRequest returns.
+func CaptureHeader(ctx context.Context, md *metadata.MD) context.Context {
+	return ctxutil.Set(ctx, headerKey{}, md)
+}
+
+// CaptureTrailer stores the trailer metadata of the response in md, like
+// grpc.Trailer. Streams store it before closing the response channel.
+func CaptureTrailer(ctx context.Context, md *metadata.MD) context.Context {
+	return ctxutil.Set(ctx, trailerKey{}, md)
+}
+
+// CaptureStatusCode stores the status code of the HTTP response in code, once
+// the response arrived. Streams store it before DoStreamingRequest returns.
+// Unlike the metadata, the status code can be captured by several callers,
+// e.g. by an interceptor and by the caller of the generated client.
+func CaptureStatusCode(ctx context.Context, code *int) context.Context {
+	dsts, _ := ctxutil.Get[statusCodeKey, []*int](ctx, statusCodeKey{})
+	return ctxutil.Set(ctx, statusCodeKey{}, append(dsts[:len(dsts):len(dsts)], code))
+}
+
+func captureStatusCode(ctx context.Context, code int) {
+	dsts, _ := ctxutil.Get[statusCodeKey, []*int](ctx, statusCodeKey{})
+	for _, dst := range dsts {
+		if dst != nil {
+			*dst = code
+		}
+	}
+}
+
+func captureHeader(ctx context.Context, md metadata.MD) {
+	if dst, ok := ctxutil.Get[headerKey, *metadata.MD](ctx, headerKey{}); ok && dst != nil {
+		*dst = md
+	}
+}
+
+func captureTrailer(ctx context.Context, md metadata.MD) {
+	if dst, ok := ctxutil.Get[trailerKey, *metadata.MD](ctx, trailerKey{}); ok && dst != nil {
+		*dst = md
+	}
+}
+
+func getResponseHeader(res *http.Response) metadata.MD {
+	md := metadata.MD{}
+	addResponseMetadata(md, res.Header, runtime.MetadataHeaderPrefix)
+	return md
+}
+
+// getResponseTrailer returns the trailer metadata of the response, which must
+// have been read to the end. Proxies may also forward it as headers.
+func getResponseTrailer(res *http.Response) metadata.MD {
+	md := metadata.MD{}
+	addResponseMetadata(md, res.Header, runtime.MetadataTrailerPrefix)
+	addResponseMetadata(md, res.Trailer, runtime.MetadataTrailerPrefix)
+	return md
+}
+
+// addResponseMetadata adds the headers with the given prefix to md. Values of
+// binary keys are decoded if they are base64-encoded.
+func addResponseMetadata(md metadata.MD, header http.Header, prefix string) {
+	for name, values := range header {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		key := strings.ToLower(name[len(prefix):])
+		for _, v := range values {
+			if strings.HasSuffix(key, binaryMetadataSuffix) {
+				if b, err := decodeBinaryMetadata(v); err == nil {
+					v = string(b)
+				}
+			}
+			md.Append(key, v)
+		}
+	}
+}
+
+func decodeBinaryMetadata(v string) ([]byte, error) {
+	if len(v)%4 == 0 {
+		return base64.StdEncoding.DecodeString(v)
+	}
+	return base64.RawStdEncoding.DecodeString(v)
+}
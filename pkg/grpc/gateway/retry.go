@@ -0,0 +1,178 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	ctxutil "github.com/akuity/grpc-gateway-client/pkg/context"
+	gwerrors "github.com/akuity/grpc-gateway-client/pkg/grpc/gateway/errors"
+)
+
+// RetryPolicy configures how failed requests are sent again. Streams are only
+// retried until their response arrives, see ReconnectPolicy for resuming them
+// afterwards.
+type RetryPolicy struct {
+	// MaxAttempts is the number of times a request is sent, including the
+	// first one.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between attempts. Requests for which the
+	// server asks to wait longer are not retried.
+	MaxBackoff time.Duration
+	// BackoffMultiplier is the factor by which the delay grows after every
+	// failed attempt.
+	BackoffMultiplier float64
+	// Jitter randomizes the delay by up to the given fraction of it, so that
+	// clients that failed together do not retry together.
+	Jitter float64
+	// RetryableCodes are the codes of the errors that are retried.
+	RetryableCodes []codes.Code
+	// RetryNonIdempotent also retries the requests that are neither sent with
+	// an idempotent HTTP method nor marked with SetIdempotent.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy retries transient failures a few times within seconds.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:       4,
+	InitialBackoff:    100 * time.Millisecond,
+	MaxBackoff:        5 * time.Second,
+	BackoffMultiplier: 2,
+	Jitter:            0.2,
+	RetryableCodes:    []codes.Code{codes.Unavailable, codes.ResourceExhausted},
+}
+
+// backoff returns the delay before the given retry, unless the server asked
+// to wait longer than the policy allows.
+func (p RetryPolicy) backoff(err error, attempt int) (time.Duration, bool) {
+	if delay, ok := getServerRetryDelay(err); ok {
+		return delay, p.MaxBackoff <= 0 || delay <= p.MaxBackoff
+	}
+	backoff := exponentialBackoff(p.InitialBackoff, p.BackoffMultiplier, p.MaxBackoff, attempt)
+	if p.Jitter > 0 {
+		backoff = time.Duration(float64(backoff) * (1 + p.Jitter*(2*rand.Float64()-1)))
+	}
+	return backoff, true
+}
+
+func exponentialBackoff(initial time.Duration, multiplier float64, maxBackoff time.Duration, attempt int) time.Duration {
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	backoff := time.Duration(float64(initial) * math.Pow(multiplier, float64(attempt)))
+	if maxBackoff > 0 && (backoff > maxBackoff || backoff < 0) {
+		backoff = maxBackoff
+	}
+	return backoff
+}
+
+// getServerRetryDelay returns the delay that the server asked for with the
+// google.rpc.RetryInfo detail or the Retry-After header of the error.
+func getServerRetryDelay(err error) (time.Duration, bool) {
+	if delay, ok := gwerrors.RetryDelay(err); ok {
+		return delay, true
+	}
+	header, _ := gwerrors.Header(err)
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		header = httpErr.Header
+	}
+	return parseRetryAfter(header.Get("Retry-After"))
+}
+
+// parseRetryAfter parses the value of the Retry-After header, which is either
+// a number of seconds or a date.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
+
+type idempotentKey struct {
+	/* explicitly empty */
+}
+
+// SetIdempotent marks the requests sent with the returned context as safe to
+// retry whatever their HTTP method. The generated clients mark the methods
+// with an idempotency_level option.
+func SetIdempotent(ctx context.Context) context.Context {
+	return ctxutil.Set(ctx, idempotentKey{}, true)
+}
+
+func isIdempotent(ctx context.Context) bool {
+	idempotent, _ := ctxutil.Get[idempotentKey, bool](ctx, idempotentKey{})
+	return idempotent
+}
+
+func isIdempotentHTTPMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// getRetryPolicy returns the retry policy of the given client, if any.
+func getRetryPolicy(c Client) (RetryPolicy, bool) {
+	if cc, ok := c.(*client); ok && cc.retryPolicy != nil {
+		return *cc.retryPolicy, true
+	}
+	return RetryPolicy{}, false
+}
+
+// retryRequest calls send until it succeeds, or until its error is not worth
+// another attempt under the retry policy of the client.
+func retryRequest(ctx context.Context, c Client, req *resty.Request, send func() error) error {
+	policy, ok := getRetryPolicy(c)
+	if !ok || policy.MaxAttempts <= 1 {
+		return send()
+	}
+	if !policy.RetryNonIdempotent && !isIdempotentHTTPMethod(req.Method) && !isIdempotent(ctx) {
+		return send()
+	}
+	if _, ok := req.Body.(io.Reader); ok {
+		// The body has been consumed by the first attempt.
+		return send()
+	}
+
+	// resty rewrites the URL with the path and query params on every send.
+	url := req.URL
+	for attempt := 1; ; attempt++ {
+		err := send()
+		if err == nil || attempt >= policy.MaxAttempts || !slices.Contains(policy.RetryableCodes, status.Code(err)) {
+			return err
+		}
+		backoff, ok := policy.backoff(err, attempt-1)
+		if !ok {
+			return err
+		}
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return status.FromContextError(ctx.Err()).Err()
+		case <-timer.C:
+		}
+		req.URL = url
+	}
+}
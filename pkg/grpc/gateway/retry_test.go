@@ -0,0 +1,225 @@
+package gateway_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/akuity/grpc-gateway-client/internal/test/gen/testv1"
+	"github.com/akuity/grpc-gateway-client/pkg/grpc/gateway"
+)
+
+// failingServer fails the first few requests with the given response.
+type failingServer struct {
+	failures   int
+	statusCode int
+	header     http.Header
+	body       []byte
+
+	attempts atomic.Int32
+}
+
+func (s *failingServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if int(s.attempts.Add(1)) <= s.failures {
+		for k, v := range s.header {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(s.statusCode)
+		_, _ = w.Write(s.body)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case req.URL.Path == "/stream":
+		_, _ = w.Write([]byte(`{"result":{"message":"1"}}` + "\n"))
+		return
+	case strings.HasSuffix(req.URL.Path, "/accept"):
+		_, _ = w.Write([]byte(`{}`))
+		return
+	}
+	_, _ = w.Write([]byte(`{"id":"1"}`))
+}
+
+func TestRetryPolicy(t *testing.T) {
+	st, err := status.New(codes.ResourceExhausted, "slow down").WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(10 * time.Millisecond),
+	})
+	require.NoError(t, err)
+	retryInfo, err := protojson.Marshal(st.Proto())
+	require.NoError(t, err)
+
+	policy := gateway.RetryPolicy{
+		MaxAttempts:       3,
+		InitialBackoff:    time.Millisecond,
+		MaxBackoff:        time.Second,
+		BackoffMultiplier: 2,
+		Jitter:            0.2,
+		RetryableCodes:    gateway.DefaultRetryPolicy.RetryableCodes,
+	}
+	testSets := map[string]struct {
+		method           string
+		idempotent       bool
+		policy           gateway.RetryPolicy
+		server           *failingServer
+		expectedCode     codes.Code
+		expectedAttempts int32
+	}{
+		"idempotent method": {
+			method:           http.MethodGet,
+			policy:           policy,
+			server:           &failingServer{failures: 2, statusCode: http.StatusServiceUnavailable},
+			expectedCode:     codes.OK,
+			expectedAttempts: 3,
+		},
+		"max attempts": {
+			method:           http.MethodGet,
+			policy:           policy,
+			server:           &failingServer{failures: 3, statusCode: http.StatusServiceUnavailable},
+			expectedCode:     codes.Unavailable,
+			expectedAttempts: 3,
+		},
+		"non-retryable code": {
+			method:           http.MethodGet,
+			policy:           policy,
+			server:           &failingServer{failures: 1, statusCode: http.StatusBadRequest},
+			expectedCode:     codes.InvalidArgument,
+			expectedAttempts: 1,
+		},
+		"non-idempotent method": {
+			method:           http.MethodPost,
+			policy:           policy,
+			server:           &failingServer{failures: 1, statusCode: http.StatusServiceUnavailable},
+			expectedCode:     codes.Unavailable,
+			expectedAttempts: 1,
+		},
+		"idempotent request": {
+			method:           http.MethodPost,
+			idempotent:       true,
+			policy:           policy,
+			server:           &failingServer{failures: 1, statusCode: http.StatusServiceUnavailable},
+			expectedCode:     codes.OK,
+			expectedAttempts: 2,
+		},
+		"retry after": {
+			method: http.MethodGet,
+			policy: policy,
+			server: &failingServer{
+				failures:   1,
+				statusCode: http.StatusTooManyRequests,
+				header:     http.Header{"Retry-After": []string{"0"}},
+			},
+			expectedCode:     codes.OK,
+			expectedAttempts: 2,
+		},
+		"retry after exceeds max backoff": {
+			method: http.MethodGet,
+			policy: policy,
+			server: &failingServer{
+				failures:   1,
+				statusCode: http.StatusServiceUnavailable,
+				header:     http.Header{"Retry-After": []string{"120"}},
+			},
+			expectedCode:     codes.Unavailable,
+			expectedAttempts: 1,
+		},
+		"retry info": {
+			method: http.MethodGet,
+			policy: policy,
+			server: &failingServer{
+				failures:   1,
+				statusCode: http.StatusTooManyRequests,
+				header:     http.Header{"Content-Type": []string{"application/json"}},
+				body:       retryInfo,
+			},
+			expectedCode:     codes.OK,
+			expectedAttempts: 2,
+		},
+	}
+	for name, ts := range testSets {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(ts.server)
+			defer srv.Close()
+			c := gateway.NewClient(srv.URL, gateway.WithRetryPolicy(ts.policy))
+			ctx := context.Background()
+			if ts.idempotent {
+				ctx = gateway.SetIdempotent(ctx)
+			}
+
+			_, err := gateway.DoRequest[testv1.SendInvitationResponse](ctx, c.NewRequest(ts.method, "/"))
+			require.Equal(t, ts.expectedCode, status.Code(err))
+			require.Equal(t, ts.expectedAttempts, ts.server.attempts.Load())
+		})
+	}
+}
+
+func TestRetryPolicy_IdempotentMethod(t *testing.T) {
+	policy := gateway.RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		RetryableCodes: []codes.Code{codes.Unavailable},
+	}
+	testSets := map[string]struct {
+		call             func(testv1.TestServiceGatewayClient) error
+		expectedCode     codes.Code
+		expectedAttempts int32
+	}{
+		"idempotency level": {
+			call: func(c testv1.TestServiceGatewayClient) error {
+				_, err := c.AcceptInvitation(context.Background(), &testv1.AcceptInvitationRequest{Id: "1"})
+				return err
+			},
+			expectedCode:     codes.OK,
+			expectedAttempts: 2,
+		},
+		"no idempotency level": {
+			call: func(c testv1.TestServiceGatewayClient) error {
+				_, err := c.SendInvitation(context.Background(), &testv1.SendInvitationRequest{Email: "test@test.com"})
+				return err
+			},
+			expectedCode:     codes.Unavailable,
+			expectedAttempts: 1,
+		},
+	}
+	for name, ts := range testSets {
+		t.Run(name, func(t *testing.T) {
+			server := &failingServer{failures: 1, statusCode: http.StatusServiceUnavailable}
+			srv := httptest.NewServer(server)
+			defer srv.Close()
+			c := gateway.NewClient(srv.URL, gateway.WithRetryPolicy(policy))
+
+			err := ts.call(testv1.NewTestServiceGatewayClient(c))
+			require.Equal(t, ts.expectedCode, status.Code(err))
+			require.Equal(t, ts.expectedAttempts, server.attempts.Load())
+		})
+	}
+}
+
+func TestRetryPolicy_Stream(t *testing.T) {
+	srv := httptest.NewServer(&failingServer{failures: 2, statusCode: http.StatusServiceUnavailable})
+	defer srv.Close()
+	c := gateway.NewClient(srv.URL, gateway.WithRetryPolicy(gateway.RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		RetryableCodes: []codes.Code{codes.Unavailable},
+	}))
+
+	resCh, errCh, err := gateway.DoStreamingRequest[testv1.TrackInvitationResponse](context.Background(), c, c.NewRequest(http.MethodGet, "/stream"))
+	require.NoError(t, err)
+	var messages []string
+	for res := range resCh {
+		messages = append(messages, res.GetMessage())
+	}
+	require.NoError(t, <-errCh)
+	require.Equal(t, []string{"1"}, messages)
+}
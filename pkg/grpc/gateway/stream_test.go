@@ -0,0 +1,488 @@
+package gateway_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/akuity/grpc-gateway-client/internal/test/gen/testv1"
+	"github.com/akuity/grpc-gateway-client/pkg/grpc/gateway"
+)
+
+// newEndlessStreamServer returns a server that streams responses until the
+// client goes away.
+func newEndlessStreamServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var frame []byte
+		if r.Header.Get("Accept") == "text/event-stream" {
+			w.Header().Set("Content-Type", "text/event-stream")
+			frame = []byte("data: {\"result\":{\"message\":\"test\"}}\n\n")
+		} else {
+			w.Header().Set("Content-Type", "application/octet-stream")
+			frame = []byte("chunk")
+		}
+		for {
+			if _, err := w.Write(frame); err != nil {
+				return
+			}
+			w.(http.Flusher).Flush()
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(time.Millisecond):
+			}
+		}
+	}))
+}
+
+func TestDoStreamingRequest_Abandon(t *testing.T) {
+	testSets := map[string]func(context.Context, gateway.Client) (<-chan error, error){
+		"server-sent events": func(ctx context.Context, c gateway.Client) (<-chan error, error) {
+			resCh, errCh, err := gateway.DoStreamingRequest[testv1.TrackInvitationResponse](ctx, c, c.NewRequest(http.MethodGet, "/"))
+			if err != nil {
+				return nil, err
+			}
+			<-resCh
+			return errCh, nil
+		},
+		"http body": func(ctx context.Context, c gateway.Client) (<-chan error, error) {
+			resCh, errCh, err := gateway.DoStreamingRequest[httpbody.HttpBody](ctx, c, c.NewRequest(http.MethodGet, "/"))
+			if err != nil {
+				return nil, err
+			}
+			<-resCh
+			return errCh, nil
+		},
+	}
+	for name, start := range testSets {
+		t.Run(name, func(t *testing.T) {
+			defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+			srv := newEndlessStreamServer()
+			defer srv.Close()
+			hc := &http.Client{}
+			defer hc.CloseIdleConnections()
+
+			ctx, cancel := context.WithCancel(context.TODO())
+			errCh, err := start(ctx, gateway.NewClient(srv.URL, gateway.WithHTTPClient(hc)))
+			require.NoError(t, err)
+
+			// Stop reading, then cancel the context to release the stream.
+			time.Sleep(10 * time.Millisecond)
+			cancel()
+			select {
+			case err := <-errCh:
+				require.Equal(t, codes.Canceled, status.Code(err))
+			case <-time.After(time.Second):
+				t.Fatal("stream was not closed after the context was canceled")
+			}
+			_, ok := <-errCh
+			require.False(t, ok)
+		})
+	}
+}
+
+func TestDoStreamingRequest_CloseContract(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("data: {\"result\":{\"message\":\"test-1\"}}\n\n"))
+		_, _ = w.Write([]byte("data: {\"result\":{\"message\":\"test-2\"}}\n\n"))
+	}))
+	defer srv.Close()
+	hc := &http.Client{}
+	defer hc.CloseIdleConnections()
+
+	c := gateway.NewClient(srv.URL, gateway.WithHTTPClient(hc))
+	resCh, errCh, err := gateway.DoStreamingRequest[testv1.TrackInvitationResponse](context.TODO(), c, c.NewRequest(http.MethodGet, "/"))
+	require.NoError(t, err)
+
+	var messages []string
+	for res := range resCh {
+		messages = append(messages, res.GetMessage())
+	}
+	require.Equal(t, []string{"test-1", "test-2"}, messages)
+	require.NoError(t, <-errCh)
+}
+
+func TestDoStreamingRequest_ContentType(t *testing.T) {
+	testSets := map[string]struct {
+		contentType string
+		body        string
+	}{
+		"server-sent events": {
+			contentType: "text/event-stream",
+			body:        "data: {\"result\":{\"message\":\"test-1\"}}\n\ndata: {\"result\":{\"message\":\"test-2\"}}\n\n",
+		},
+		"newline-delimited json": {
+			contentType: "application/json",
+			body:        "{\"result\":{\"message\":\"test-1\"}}\n{\"result\":{\"message\":\"test-2\"}}\n",
+		},
+		"newline-delimited json with parameters": {
+			contentType: "application/json; charset=utf-8",
+			body:        "{\"result\":{\"message\":\"test-1\"}}\n{\"result\":{\"message\":\"test-2\"}}",
+		},
+	}
+	for name, ts := range testSets {
+		t.Run(name, func(t *testing.T) {
+			var accept []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				accept = r.Header.Values("Accept")
+				w.Header().Set("Content-Type", ts.contentType)
+				_, _ = w.Write([]byte(ts.body))
+			}))
+			defer srv.Close()
+
+			c := gateway.NewClient(srv.URL)
+			resCh, errCh, err := gateway.DoStreamingRequest[testv1.TrackInvitationResponse](context.TODO(), c, c.NewRequest(http.MethodGet, "/"))
+			require.NoError(t, err)
+
+			var messages []string
+			for res := range resCh {
+				messages = append(messages, res.GetMessage())
+			}
+			require.NoError(t, <-errCh)
+			require.Equal(t, []string{"test-1", "test-2"}, messages)
+			require.Equal(t, []string{"text/event-stream", "application/json"}, accept)
+		})
+	}
+}
+
+func TestDoStreamingRequest_Reconnect(t *testing.T) {
+	// Every connection replays the events after Last-Event-ID and the last
+	// delivered one, then drops in the middle of the next event.
+	events := []string{"test-1", "test-2", "test-3"}
+	testSets := map[string]struct {
+		policy *gateway.ReconnectPolicy
+		drops  int
+		// stall makes the reconnections drop before any new event.
+		stall            bool
+		expectedMessages []string
+		expectedLastIDs  []string
+		errExpected      bool
+	}{
+		"resume after failures": {
+			policy: &gateway.ReconnectPolicy{
+				MaxRetries: 2,
+				// The retry hint of the server takes precedence.
+				InitialBackoff: time.Minute,
+			},
+			drops:            2,
+			expectedMessages: events,
+			expectedLastIDs:  []string{"", "1", "2"},
+		},
+		"give up after max retries": {
+			policy: &gateway.ReconnectPolicy{
+				MaxRetries:     1,
+				InitialBackoff: time.Millisecond,
+			},
+			drops:            3,
+			stall:            true,
+			expectedMessages: []string{"test-1"},
+			expectedLastIDs:  []string{"", "1"},
+			errExpected:      true,
+		},
+		"without reconnect policy": {
+			drops:            1,
+			expectedMessages: []string{"test-1"},
+			expectedLastIDs:  []string{""},
+			errExpected:      true,
+		},
+	}
+	for name, ts := range testSets {
+		t.Run(name, func(t *testing.T) {
+			var lastIDs []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				lastID := r.Header.Get("Last-Event-ID")
+				attempt := len(lastIDs)
+				lastIDs = append(lastIDs, lastID)
+
+				w.Header().Set("Content-Type", "text/event-stream")
+				_, _ = w.Write([]byte("retry: 1\n\n"))
+				start, _ := strconv.Atoi(lastID)
+				if start > 0 {
+					// Replay the last delivered event.
+					start--
+				}
+				for i := start; i < len(events); i++ {
+					if attempt < ts.drops && (i > attempt || ts.stall && attempt > 0) {
+						_, _ = fmt.Fprintf(w, "id: %d\ndata: {\"result\"", i+1)
+						w.(http.Flusher).Flush()
+						panic(http.ErrAbortHandler)
+					}
+					_, _ = fmt.Fprintf(w, "id: %d\ndata: {\"result\":{\"message\":%q}}\n\n", i+1, events[i])
+				}
+			}))
+			defer srv.Close()
+
+			ctx := context.TODO()
+			if ts.policy != nil {
+				ctx = gateway.SetReconnectPolicy(ctx, *ts.policy)
+			}
+			c := gateway.NewClient(srv.URL)
+			resCh, errCh, err := gateway.DoStreamingRequest[testv1.TrackInvitationResponse](ctx, c, c.NewRequest(http.MethodGet, "/"))
+			require.NoError(t, err)
+
+			var messages []string
+			for res := range resCh {
+				messages = append(messages, res.GetMessage())
+			}
+			err = <-errCh
+			require.Equal(t, ts.expectedMessages, messages)
+			require.Equal(t, ts.expectedLastIDs, lastIDs)
+			if ts.errExpected {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDoStreamingRequest_RepeatedEventIDs(t *testing.T) {
+	// Event ids need not be unique, e.g. with WithEventIDField.
+	testSets := map[string]*gateway.ReconnectPolicy{
+		"without reconnect policy": nil,
+		"with reconnect policy":    &gateway.DefaultReconnectPolicy,
+	}
+	for name, policy := range testSets {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "text/event-stream")
+				_, _ = w.Write([]byte("id: inv-1\ndata: {\"result\":{\"message\":\"seen\"}}\n\n" +
+					"id: inv-1\ndata: {\"result\":{\"message\":\"accepted\"}}\n\n"))
+			}))
+			defer srv.Close()
+
+			ctx := context.TODO()
+			if policy != nil {
+				ctx = gateway.SetReconnectPolicy(ctx, *policy)
+			}
+			c := gateway.NewClient(srv.URL)
+			resCh, errCh, err := gateway.DoStreamingRequest[testv1.TrackInvitationResponse](ctx, c, c.NewRequest(http.MethodGet, "/"))
+			require.NoError(t, err)
+
+			var messages []string
+			for res := range resCh {
+				messages = append(messages, res.GetMessage())
+			}
+			require.NoError(t, <-errCh)
+			require.Equal(t, []string{"seen", "accepted"}, messages)
+		})
+	}
+}
+
+func TestDoStreamingRequest_SlowConsumer(t *testing.T) {
+	// The time the consumer takes to handle the messages is not idle time.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for i := 1; i <= 4; i++ {
+			_, _ = fmt.Fprintf(w, "data: {\"result\":{\"message\":\"test-%d\"}}\n\n", i)
+			w.(http.Flusher).Flush()
+			time.Sleep(10 * time.Millisecond)
+		}
+	}))
+	defer srv.Close()
+
+	c := gateway.NewClient(srv.URL, gateway.WithStreamIdleTimeout(100*time.Millisecond))
+	resCh, errCh, err := gateway.DoStreamingRequest[testv1.TrackInvitationResponse](context.TODO(), c, c.NewRequest(http.MethodGet, "/"))
+	require.NoError(t, err)
+
+	var messages []string
+	for res := range resCh {
+		messages = append(messages, res.GetMessage())
+		time.Sleep(200 * time.Millisecond)
+	}
+	require.NoError(t, <-errCh)
+	require.Equal(t, []string{"test-1", "test-2", "test-3", "test-4"}, messages)
+}
+
+func TestDoHTTPBodyStreamingRequest_IdleTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write([]byte("chunk"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	c := gateway.NewClient(srv.URL, gateway.WithStreamIdleTimeout(50*time.Millisecond))
+	body, err := gateway.DoHTTPBodyStreamingRequest(context.TODO(), c, c.NewRequest(http.MethodGet, "/"))
+	require.NoError(t, err)
+	defer func() { _ = body.Close() }()
+
+	data, err := io.ReadAll(body)
+	require.Equal(t, "chunk", string(data))
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
+
+func TestDoStreamingRequest_ErrorEvent(t *testing.T) {
+	testSets := map[string]string{
+		"error chunk": "event: error\ndata: {\"error\":{\"code\":9,\"message\":\"invitation expired\"}}\n\n",
+		"bare status": "event: error\ndata: {\"code\":9,\"message\":\"invitation expired\"}\n\n",
+	}
+	for name, body := range testSets {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "text/event-stream")
+				_, _ = w.Write([]byte(": keepalive\n\ndata: {\"result\":{\"message\":\"test-1\"}}\n\n" + body))
+			}))
+			defer srv.Close()
+
+			c := gateway.NewClient(srv.URL)
+			resCh, errCh, err := gateway.DoStreamingRequest[testv1.TrackInvitationResponse](context.TODO(), c, c.NewRequest(http.MethodGet, "/"))
+			require.NoError(t, err)
+
+			var messages []string
+			for res := range resCh {
+				messages = append(messages, res.GetMessage())
+			}
+			require.Equal(t, []string{"test-1"}, messages)
+			err = <-errCh
+			require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			require.Equal(t, "invitation expired", status.Convert(err).Message())
+		})
+	}
+}
+
+func TestDoStreamingRequest_Timeout(t *testing.T) {
+	testSets := map[string]struct {
+		opts             []gateway.ClientOption
+		keepAlive        bool
+		expectedMessages []string
+		expectedErr      string
+	}{
+		"idle timeout": {
+			opts: []gateway.ClientOption{
+				gateway.WithStreamIdleTimeout(50 * time.Millisecond),
+			},
+			expectedMessages: []string{"test-1"},
+			expectedErr:      "stream idle timeout exceeded",
+		},
+		"keepalives reset idle timeout": {
+			opts: []gateway.ClientOption{
+				gateway.WithStreamIdleTimeout(50 * time.Millisecond),
+			},
+			keepAlive:        true,
+			expectedMessages: []string{"test-1", "test-2"},
+		},
+		"max duration": {
+			opts: []gateway.ClientOption{
+				gateway.WithStreamIdleTimeout(50 * time.Millisecond),
+				gateway.WithStreamMaxDuration(100 * time.Millisecond),
+			},
+			keepAlive:        true,
+			expectedMessages: []string{"test-1"},
+			expectedErr:      "stream max duration exceeded",
+		},
+	}
+	for name, ts := range testSets {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/event-stream")
+				_, _ = w.Write([]byte("data: {\"result\":{\"message\":\"test-1\"}}\n\n"))
+				w.(http.Flusher).Flush()
+				// Stay silent, or send keepalives only, for a while.
+				for i := 0; i < 15; i++ {
+					select {
+					case <-r.Context().Done():
+						return
+					case <-time.After(10 * time.Millisecond):
+					}
+					if ts.keepAlive {
+						_, _ = w.Write([]byte(": keepalive\n\n"))
+						w.(http.Flusher).Flush()
+					}
+				}
+				_, _ = w.Write([]byte("data: {\"result\":{\"message\":\"test-2\"}}\n\n"))
+			}))
+			defer srv.Close()
+
+			c := gateway.NewClient(srv.URL, ts.opts...)
+			resCh, errCh, err := gateway.DoStreamingRequest[testv1.TrackInvitationResponse](context.TODO(), c, c.NewRequest(http.MethodGet, "/"))
+			require.NoError(t, err)
+
+			var messages []string
+			for res := range resCh {
+				messages = append(messages, res.GetMessage())
+			}
+			err = <-errCh
+			require.Equal(t, ts.expectedMessages, messages)
+			if ts.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+			require.Equal(t, ts.expectedErr, status.Convert(err).Message())
+		})
+	}
+}
+
+func TestDoStreamingRequest_HTTPBodyIdleTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write([]byte("chunk"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	c := gateway.NewClient(srv.URL, gateway.WithStreamIdleTimeout(50*time.Millisecond))
+	resCh, errCh, err := gateway.DoStreamingRequest[httpbody.HttpBody](context.TODO(), c, c.NewRequest(http.MethodGet, "/"))
+	require.NoError(t, err)
+
+	var data []byte
+	for res := range resCh {
+		data = append(data, res.GetData()...)
+	}
+	require.Equal(t, "chunk", string(data))
+	require.Equal(t, codes.DeadlineExceeded, status.Code(<-errCh))
+}
+
+func TestDoStreamingRequest_CaptureTrailer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "trailers", r.Header.Get("TE"))
+		w.Header().Set("Trailer", "Grpc-Trailer-Invitation-Status")
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("data: {\"result\":{\"message\":\"test\"}}\n\n"))
+		w.Header().Set("Grpc-Trailer-Invitation-Status", "done")
+	}))
+	defer srv.Close()
+	c := gateway.NewClient(srv.URL)
+
+	for name, newRequest := range map[string]func(ctx context.Context) (<-chan error, error){
+		"messages": func(ctx context.Context) (<-chan error, error) {
+			resCh, errCh, err := gateway.DoStreamingRequest[testv1.TrackInvitationResponse](ctx, c, c.NewRequest(http.MethodGet, "/"))
+			for range resCh {
+			}
+			return errCh, err
+		},
+		"http body": func(ctx context.Context) (<-chan error, error) {
+			resCh, errCh, err := gateway.DoStreamingRequest[httpbody.HttpBody](ctx, c, c.NewRequest(http.MethodGet, "/"))
+			for range resCh {
+			}
+			return errCh, err
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var trailer metadata.MD
+			errCh, err := newRequest(gateway.CaptureTrailer(context.Background(), &trailer))
+			require.NoError(t, err)
+			require.NoError(t, <-errCh)
+			require.Equal(t, []string{"done"}, trailer.Get("invitation-status"))
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	errStreamIdleTimeout = status.Error(codes.DeadlineExceeded, "stream idle timeout exceeded")
+	errStreamMaxDuration = status.Error(codes.DeadlineExceeded, "stream max duration exceeded")
+)
+
+// withStreamMaxDuration returns a context that is canceled with
+// errStreamMaxDuration once d elapses. A zero duration means no limit.
+func withStreamMaxDuration(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeoutCause(ctx, d, errStreamMaxDuration)
+}
+
+// idleTimeoutReader closes the body when a read waits for the server longer
+// than the timeout, so that streams from silent servers fail instead of
+// blocking. The time between the reads, which the consumer spends handling
+// the data, does not count.
+type idleTimeoutReader struct {
+	body     io.ReadCloser
+	timeout  time.Duration
+	timer    *time.Timer
+	timedOut atomic.Bool
+}
+
+func newIdleTimeoutReader(body io.ReadCloser, timeout time.Duration) io.ReadCloser {
+	if timeout <= 0 {
+		return body
+	}
+	r := &idleTimeoutReader{
+		body:    body,
+		timeout: timeout,
+	}
+	r.timer = time.AfterFunc(timeout, func() {
+		r.timedOut.Store(true)
+		_ = r.body.Close()
+	})
+	r.timer.Stop()
+	return r
+}
+
+func (r *idleTimeoutReader) Read(p []byte) (int, error) {
+	r.timer.Reset(r.timeout)
+	n, err := r.body.Read(p)
+	r.timer.Stop()
+	if r.timedOut.Load() {
+		return n, errStreamIdleTimeout
+	}
+	return n, err
+}
+
+func (r *idleTimeoutReader) Close() error {
+	r.timer.Stop()
+	return r.body.Close()
+}
@@ -0,0 +1,170 @@
+package gateway_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/akuity/grpc-gateway-client/internal/test/gen/testv1"
+	"github.com/akuity/grpc-gateway-client/pkg/grpc/gateway"
+	httpctx "github.com/akuity/grpc-gateway-client/pkg/http/context"
+)
+
+// tokenSourceFunc gets a new token on every call.
+type tokenSourceFunc func() (*oauth2.Token, error)
+
+func (f tokenSourceFunc) Token() (*oauth2.Token, error) {
+	return f()
+}
+
+func TestTokenSource(t *testing.T) {
+	var issued int32
+	tokenSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if _, secret, _ := req.BasicAuth(); secret != "secret" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+		n := atomic.AddInt32(&issued, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":3600}`, n)
+	}))
+	defer tokenSrv.Close()
+
+	// The first token is revoked before it expires.
+	var tokens []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		auth := req.Header.Get("Authorization")
+		tokens = append(tokens, auth)
+		w.Header().Set("Content-Type", "application/json")
+		if auth != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"code":16,"message":"token revoked"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"some-id"}`))
+	}))
+	defer srv.Close()
+
+	testSets := map[string]struct {
+		secret         string
+		expectedCode   codes.Code
+		expectedTokens []string
+	}{
+		"revoked token": {
+			secret:         "secret",
+			expectedCode:   codes.OK,
+			expectedTokens: []string{"Bearer token-1", "Bearer token-2"},
+		},
+		"invalid client": {
+			secret:       "invalid",
+			expectedCode: codes.Unauthenticated,
+		},
+	}
+	for name, ts := range testSets {
+		t.Run(name, func(t *testing.T) {
+			atomic.StoreInt32(&issued, 0)
+			tokens = nil
+			cfg := &clientcredentials.Config{
+				ClientID:     "client",
+				ClientSecret: ts.secret,
+				TokenURL:     tokenSrv.URL,
+			}
+			hc := &http.Client{}
+			client := testv1.NewTestServiceGatewayClient(gateway.NewClient(srv.URL,
+				gateway.WithHTTPClient(hc),
+				gateway.WithTokenSource(tokenSourceFunc(func() (*oauth2.Token, error) {
+					return cfg.Token(context.TODO())
+				})),
+			))
+
+			_, err := client.SendInvitation(context.TODO(), &testv1.SendInvitationRequest{
+				Email: "test@test.com",
+			})
+			require.Equal(t, ts.expectedCode, status.Code(err))
+			require.Equal(t, ts.expectedTokens, tokens)
+			// The transport of the caller is not wrapped.
+			require.IsType(t, &http.Transport{}, hc.Transport)
+		})
+	}
+}
+
+func TestTokenSource_WebSocket(t *testing.T) {
+	// The first token is revoked before it expires.
+	var tokens []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		auth := req.Header.Get("Authorization")
+		tokens = append(tokens, auth)
+		if auth != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, req, nil)
+		if err != nil {
+			return
+		}
+		_ = conn.Close()
+	}))
+	defer srv.Close()
+
+	var issued int32
+	client := testv1.NewTestServiceGatewayClient(gateway.NewClient(srv.URL,
+		gateway.WithTokenSource(tokenSourceFunc(func() (*oauth2.Token, error) {
+			n := atomic.AddInt32(&issued, 1)
+			return &oauth2.Token{AccessToken: fmt.Sprintf("token-%d", n), TokenType: "Bearer"}, nil
+		})),
+	))
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	_, err := client.DiscussInvitation(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Bearer token-1", "Bearer token-2"}, tokens)
+}
+
+func TestAuthorizationHeader_WebSocket(t *testing.T) {
+	testSets := map[string]struct {
+		opts []gateway.ClientOption
+	}{
+		"without token source": {},
+		"with token source": {
+			opts: []gateway.ClientOption{
+				gateway.WithTokenSource(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "source-token"})),
+			},
+		},
+	}
+	for name, ts := range testSets {
+		t.Run(name, func(t *testing.T) {
+			var auth string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				auth = req.Header.Get("Authorization")
+				conn, err := (&websocket.Upgrader{}).Upgrade(w, req, nil)
+				if err != nil {
+					return
+				}
+				_ = conn.Close()
+			}))
+			defer srv.Close()
+			client := testv1.NewTestServiceGatewayClient(gateway.NewClient(srv.URL, ts.opts...))
+
+			// The authorization header of the context takes precedence, as
+			// with the authorization header injector.
+			ctx, cancel := context.WithCancel(httpctx.SetAuthorizationHeader(context.TODO(), "Bearer", "context-token"))
+			defer cancel()
+			_, err := client.DiscussInvitation(ctx)
+			require.NoError(t, err)
+			require.Equal(t, "Bearer context-token", auth)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package gateway
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"syscall"
+
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ interface {
+	error
+	GRPCStatus() *status.Status
+} = &transportError{}
+
+// transportError is a failure to send a request or to read its response,
+// classified into the gRPC code that a native gRPC client would report.
+type transportError struct {
+	op   string
+	code codes.Code
+	err  error
+}
+
+// newTransportError wraps the error of the given operation. Errors that
+// already carry a status, such as stream timeouts, are returned as is.
+func newTransportError(ctx context.Context, op string, err error) error {
+	var se interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &se) {
+		return err
+	}
+	return &transportError{
+		op:   op,
+		code: getTransportErrorCode(ctx, err),
+		err:  err,
+	}
+}
+
+func (e *transportError) Error() string {
+	return e.op + ": " + e.err.Error()
+}
+
+func (e *transportError) GRPCStatus() *status.Status {
+	return status.New(e.code, e.Error())
+}
+
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+func getTransportErrorCode(ctx context.Context, err error) codes.Code {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return status.FromContextError(ctxErr).Code()
+	}
+
+	var netErr net.Error
+	var opErr *net.OpError
+	var certErr *tls.CertificateVerificationError
+	var recordErr tls.RecordHeaderError
+	var authorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	var retrieveErr *oauth2.RetrieveError
+	switch {
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return codes.DeadlineExceeded
+	case errors.As(err, &opErr),
+		errors.Is(err, syscall.ECONNREFUSED),
+		errors.Is(err, syscall.ECONNRESET),
+		errors.Is(err, syscall.EPIPE),
+		errors.Is(err, io.EOF),
+		errors.Is(err, io.ErrUnexpectedEOF),
+		errors.As(err, &certErr),
+		errors.As(err, &recordErr),
+		errors.As(err, &authorityErr),
+		errors.As(err, &hostnameErr),
+		errors.As(err, &invalidErr):
+		// gRPC reports connection and handshake failures as Unavailable.
+		return codes.Unavailable
+	case errors.As(err, &retrieveErr):
+		// The token endpoint rejected the credentials of the client.
+		return codes.Unauthenticated
+	default:
+		return codes.Unknown
+	}
+}
@@ -0,0 +1,104 @@
+package gateway_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/akuity/grpc-gateway-client/internal/test/gen/testv1"
+	"github.com/akuity/grpc-gateway-client/pkg/grpc/gateway"
+)
+
+func TestTransportError(t *testing.T) {
+	blocking := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer blocking.Close()
+	resetting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		conn, _, err := w.(http.Hijacker).Hijack()
+		require.NoError(t, err)
+		_ = conn.(*net.TCPConn).SetLinger(0)
+		_ = conn.Close()
+	}))
+	defer resetting.Close()
+	untrusted := httptest.NewTLSServer(http.NotFoundHandler())
+	defer untrusted.Close()
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	testSets := map[string]struct {
+		url          string
+		opts         []gateway.ClientOption
+		ctx          func() (context.Context, context.CancelFunc)
+		expectedCode codes.Code
+		expectedErr  error
+	}{
+		"connection refused": {
+			url:          closed.URL,
+			expectedCode: codes.Unavailable,
+			expectedErr:  syscall.ECONNREFUSED,
+		},
+		"connection reset": {
+			url:          resetting.URL,
+			expectedCode: codes.Unavailable,
+		},
+		"untrusted certificate": {
+			url:          untrusted.URL,
+			expectedCode: codes.Unavailable,
+		},
+		"canceled": {
+			url: blocking.URL,
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				time.AfterFunc(50*time.Millisecond, cancel)
+				return ctx, cancel
+			},
+			expectedCode: codes.Canceled,
+			expectedErr:  context.Canceled,
+		},
+		"deadline exceeded": {
+			url: blocking.URL,
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 50*time.Millisecond)
+			},
+			expectedCode: codes.DeadlineExceeded,
+			expectedErr:  context.DeadlineExceeded,
+		},
+		"client timeout": {
+			url: blocking.URL,
+			opts: []gateway.ClientOption{
+				gateway.WithHTTPClient(&http.Client{Timeout: 50 * time.Millisecond}),
+			},
+			expectedCode: codes.DeadlineExceeded,
+		},
+	}
+	for name, ts := range testSets {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			if ts.ctx != nil {
+				ctx, cancel = ts.ctx()
+			}
+			defer cancel()
+			c := gateway.NewClient(ts.url, ts.opts...)
+
+			_, unaryErr := gateway.DoRequest[testv1.SendInvitationResponse](ctx, c.NewRequest(http.MethodPost, "/"))
+			_, _, streamingErr := gateway.DoStreamingRequest[testv1.TrackInvitationResponse](ctx, c, c.NewRequest(http.MethodGet, "/"))
+			for _, err := range []error{unaryErr, streamingErr} {
+				require.Error(t, err)
+				require.Equal(t, ts.expectedCode, status.Code(err), err.Error())
+				if ts.expectedErr != nil {
+					require.True(t, errors.Is(err, ts.expectedErr))
+				}
+			}
+		})
+	}
+}
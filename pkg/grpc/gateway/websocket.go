@@ -0,0 +1,347 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/gorilla/websocket"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	httpctx "github.com/akuity/grpc-gateway-client/pkg/http/context"
+)
+
+const (
+	// WebSocketMethodParam is the query parameter that carries the HTTP method
+	// of the proxied request, since the WebSocket handshake is always a GET.
+	WebSocketMethodParam = "method"
+
+	// WebSocketEndOfSendMessage is the text message that CloseSend sends,
+	// since WebSocket connections cannot be half-closed. The proxy must end
+	// the request body when it receives it, and keep forwarding the responses
+	// until the server ends the stream.
+	WebSocketEndOfSendMessage = "EOF"
+
+	// webSocketStatusCloseCodeBase is the first close code of the application
+	// range used to carry gRPC status codes (4000 + code).
+	webSocketStatusCloseCodeBase = 4000
+)
+
+// BidiStream is the client side of a bidirectional streaming RPC.
+type BidiStream[Req, Res any] interface {
+	// Send sends a request message to the server.
+	Send(*Req) error
+	// Recv receives a response message from the server. It returns io.EOF
+	// when the server closes the stream normally.
+	Recv() (*Res, error)
+	// CloseSend closes the sending direction of the stream.
+	CloseSend() error
+}
+
+// webSocketDialer is implemented by the clients that support bidirectional
+// streams.
+type webSocketDialer interface {
+	dialWebSocket(ctx context.Context, req *resty.Request) (*websocket.Conn, *http.Response, error)
+}
+
+// DoBidiStreamingRequest starts a bidirectional stream over WebSocket. Like
+// with gRPC streams, the connection is only released once ctx is done, or
+// once Recv returns an error, io.EOF included, so callers that stop before
+// must cancel ctx.
+//
+// The WebSocket handshake is not sent through the transport of the HTTP
+// client, so custom RoundTrippers do not apply to it. Its proxy, dialer and
+// TLS settings are used, as well as the token source, the per-RPC
+// credentials and the authorization header set with
+// httpctx.SetAuthorizationHeader.
+func DoBidiStreamingRequest[Req, Res any](ctx context.Context, c Client, req *resty.Request) (BidiStream[Req, Res], error) {
+	d, ok := c.(webSocketDialer)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "%T does not support bidirectional streams", c)
+	}
+	conn, res, err := d.dialWebSocket(ctx, req)
+	if res != nil {
+		captureHeader(ctx, getResponseHeader(res))
+		captureStatusCode(ctx, res.StatusCode)
+	}
+	if err != nil {
+		if res != nil && errors.Is(err, websocket.ErrBadHandshake) {
+			return nil, wrapWebSocketHandshakeError(c, res)
+		}
+		return nil, newTransportError(ctx, "dial websocket", err)
+	}
+
+	stream := &bidiStream[Req, Res]{
+		ctx:  ctx,
+		c:    c,
+		conn: conn,
+		done: make(chan struct{}),
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			stream.close()
+		case <-stream.done:
+		}
+	}()
+	return stream, nil
+}
+
+type bidiStream[Req, Res any] struct {
+	ctx  context.Context
+	c    Client
+	conn *websocket.Conn
+
+	writeMu    sync.Mutex
+	sendClosed bool
+	closeOnce  sync.Once
+	done       chan struct{}
+}
+
+func (s *bidiStream[Req, Res]) Send(req *Req) error {
+	data, err := s.c.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("marshal request: %w", err)
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	if s.sendClosed {
+		return status.Error(codes.Internal, "send called after close send")
+	}
+	if err := s.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+		// The connection cannot be used anymore.
+		s.close()
+		return s.wrapError(err)
+	}
+	return nil
+}
+
+func (s *bidiStream[Req, Res]) Recv() (*Res, error) {
+	for {
+		_, msg, err := s.conn.ReadMessage()
+		if err != nil {
+			s.close()
+			return nil, s.wrapError(err)
+		}
+
+		var res streamingResponse
+		if err := json.Unmarshal(msg, &res); err != nil {
+			s.close()
+			return nil, fmt.Errorf("unmarshal streaming response: %w", err)
+		}
+		if rawErrRes, ok := res[streamingResponseErrorKey]; ok {
+			s.close()
+			return nil, unmarshalStreamingError(s.c, rawErrRes)
+		}
+		rawResult, ok := res[streamingResponseResultKey]
+		if !ok {
+			continue
+		}
+
+		var data Res
+		if err := s.c.Unmarshal(rawResult, &data); err != nil {
+			s.close()
+			return nil, err
+		}
+		return &data, nil
+	}
+}
+
+// CloseSend tells the proxy that no more requests will be sent, with
+// WebSocketEndOfSendMessage. A close frame would make the peer close the
+// connection before the responses in flight are received.
+func (s *bidiStream[Req, Res]) CloseSend() error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	if s.sendClosed {
+		return nil
+	}
+	s.sendClosed = true
+	if err := s.conn.WriteMessage(websocket.TextMessage, []byte(WebSocketEndOfSendMessage)); err != nil {
+		s.close()
+		return s.wrapError(err)
+	}
+	return nil
+}
+
+func (s *bidiStream[Req, Res]) close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		_ = s.conn.Close()
+	})
+}
+
+func (s *bidiStream[Req, Res]) wrapError(err error) error {
+	if ctxErr := s.ctx.Err(); ctxErr != nil {
+		return status.FromContextError(ctxErr).Err()
+	}
+	var closeErr *websocket.CloseError
+	if !errors.As(err, &closeErr) {
+		return newTransportError(s.ctx, "websocket connection", err)
+	}
+	if closeErr.Code == websocket.CloseNormalClosure {
+		return io.EOF
+	}
+	return status.Error(WebSocketCloseCodeToCode(closeErr.Code), closeErr.Text)
+}
+
+func (c *client) dialWebSocket(ctx context.Context, req *resty.Request) (*websocket.Conn, *http.Response, error) {
+	u, err := c.webSocketURL(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	header := http.Header{}
+	for k, vs := range c.rc.Header {
+		header[k] = append([]string(nil), vs...)
+	}
+	for k, vs := range req.Header {
+		header[k] = append([]string(nil), vs...)
+	}
+	c.setContextHeaders(ctx, header)
+	if err := c.setCredentialHeaders(ctx, u, header); err != nil {
+		return nil, nil, err
+	}
+
+	// The handshake does not go through the transport of the HTTP client, so
+	// the authorization that the transports of this module add is added here.
+	// An authorization header set in the context takes precedence, like the
+	// authorization header injector does.
+	if auth, ok := httpctx.GetAuthorizationHeader(ctx); ok && auth != "" {
+		header.Set("Authorization", auth)
+		return c.newWebSocketDialer().DialContext(ctx, u.String(), header)
+	}
+	if c.tokenSource == nil {
+		return c.newWebSocketDialer().DialContext(ctx, u.String(), header)
+	}
+	token, err := c.tokenSource.Token()
+	if err != nil {
+		return nil, nil, err
+	}
+	conn, res, err := c.dialWebSocketWithToken(ctx, u, header, token)
+	if res == nil || res.StatusCode != http.StatusUnauthorized {
+		return conn, res, err
+	}
+
+	// Like the HTTP requests, retry once with a new token, since the token may
+	// have been revoked before it expired.
+	c.tokenSource.Invalidate(token)
+	newToken, tokenErr := c.tokenSource.Token()
+	if tokenErr != nil || newToken.AccessToken == token.AccessToken {
+		return conn, res, err
+	}
+	return c.dialWebSocketWithToken(ctx, u, header, newToken)
+}
+
+func (c *client) dialWebSocketWithToken(ctx context.Context, u *url.URL, header http.Header, token *oauth2.Token) (*websocket.Conn, *http.Response, error) {
+	header.Set("Authorization", token.Type()+" "+token.AccessToken)
+	return c.newWebSocketDialer().DialContext(ctx, u.String(), header)
+}
+
+func (c *client) newWebSocketDialer() *websocket.Dialer {
+	d := &websocket.Dialer{
+		HandshakeTimeout: c.httpClient.Timeout,
+		Jar:              c.httpClient.Jar,
+	}
+	if ht, ok := getHTTPTransport(c.httpClient); ok {
+		d.Proxy = ht.Proxy
+		d.NetDialContext = ht.DialContext
+		if ht.TLSClientConfig != nil {
+			d.TLSClientConfig = ht.TLSClientConfig.Clone()
+		}
+	}
+	return d
+}
+
+func (c *client) webSocketURL(req *resty.Request) (*url.URL, error) {
+	path := req.URL
+	for k, v := range req.PathParams {
+		path = strings.ReplaceAll(path, "{"+k+"}", url.PathEscape(v))
+	}
+
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, fmt.Errorf("parse url: %w", err)
+	}
+	if !u.IsAbs() {
+		u, err = url.Parse(strings.TrimRight(c.rc.BaseURL, "/") + "/" + strings.TrimLeft(path, "/"))
+		if err != nil {
+			return nil, fmt.Errorf("parse url: %w", err)
+		}
+	}
+	switch u.Scheme {
+	case "http":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	}
+
+	q := u.Query()
+	for k, vs := range req.QueryParam {
+		for _, v := range vs {
+			q.Add(k, v)
+		}
+	}
+	q.Set(WebSocketMethodParam, req.Method)
+	u.RawQuery = q.Encode()
+	return u, nil
+}
+
+func wrapWebSocketHandshakeError(c Client, res *http.Response) error {
+	defer func() { _ = res.Body.Close() }()
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("read error response body: %w", err)
+	}
+	return unmarshalErrorResponse(c, res.StatusCode, res.Header, data)
+}
+
+// WebSocketCloseCodeToCode converts WebSocket close code to gRPC error code.
+// Close codes in the range 4000-4016 carry the gRPC code directly.
+func WebSocketCloseCodeToCode(code int) codes.Code {
+	if code >= webSocketStatusCloseCodeBase && code <= webSocketStatusCloseCodeBase+int(codes.Unauthenticated) {
+		return codes.Code(code - webSocketStatusCloseCodeBase)
+	}
+
+	switch code {
+	case websocket.CloseNormalClosure:
+		return codes.OK
+	case websocket.CloseGoingAway,
+		websocket.CloseAbnormalClosure,
+		websocket.CloseServiceRestart,
+		websocket.CloseTryAgainLater,
+		websocket.CloseTLSHandshake:
+		return codes.Unavailable
+	case websocket.CloseProtocolError, websocket.CloseInternalServerErr:
+		return codes.Internal
+	case websocket.CloseUnsupportedData, websocket.CloseInvalidFramePayloadData:
+		return codes.InvalidArgument
+	case websocket.ClosePolicyViolation:
+		return codes.PermissionDenied
+	case websocket.CloseMessageTooBig:
+		return codes.ResourceExhausted
+	case websocket.CloseMandatoryExtension:
+		return codes.Unimplemented
+	default:
+		return codes.Unknown
+	}
+}
+
+// CodeToWebSocketCloseCode converts gRPC error code to the WebSocket close
+// code understood by WebSocketCloseCodeToCode.
+func CodeToWebSocketCloseCode(code codes.Code) int {
+	if code == codes.OK {
+		return websocket.CloseNormalClosure
+	}
+	return webSocketStatusCloseCodeBase + int(code)
+}
@@ -0,0 +1,48 @@
+package gateway
+
+import (
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestWebSocketCloseCodeToCode(t *testing.T) {
+	testSets := map[string]struct {
+		input    int
+		expected codes.Code
+	}{
+		"normal closure": {
+			input:    websocket.CloseNormalClosure,
+			expected: codes.OK,
+		},
+		"abnormal closure": {
+			input:    websocket.CloseAbnormalClosure,
+			expected: codes.Unavailable,
+		},
+		"message too big": {
+			input:    websocket.CloseMessageTooBig,
+			expected: codes.ResourceExhausted,
+		},
+		"status close code": {
+			input:    4000 + int(codes.NotFound),
+			expected: codes.NotFound,
+		},
+		"unknown application close code": {
+			input:    4999,
+			expected: codes.Unknown,
+		},
+	}
+	for name, ts := range testSets {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, ts.expected, WebSocketCloseCodeToCode(ts.input))
+		})
+	}
+}
+
+func TestCodeToWebSocketCloseCode(t *testing.T) {
+	for code := codes.OK; code <= codes.Unauthenticated; code++ {
+		require.Equal(t, code, WebSocketCloseCodeToCode(CodeToWebSocketCloseCode(code)))
+	}
+}
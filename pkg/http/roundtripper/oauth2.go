@@ -0,0 +1,117 @@
+package roundtripper
+
+import (
+	"io"
+	"net/http"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+var (
+	_ WrappedRoundTripper = &tokenSourceInjector{}
+	_ oauth2.TokenSource  = &RefreshingTokenSource{}
+)
+
+// RefreshingTokenSource caches the token of its source. The token is
+// refreshed shortly before it expires, or once it is invalidated. The source
+// should get a new token on every call, like the Token method of
+// clientcredentials.Config does, so that invalidated tokens are replaced.
+type RefreshingTokenSource struct {
+	src oauth2.TokenSource
+
+	mu    sync.Mutex
+	token *oauth2.Token
+}
+
+func NewRefreshingTokenSource(src oauth2.TokenSource) *RefreshingTokenSource {
+	if ts, ok := src.(*RefreshingTokenSource); ok {
+		return ts
+	}
+	return &RefreshingTokenSource{
+		src: src,
+	}
+}
+
+func (s *RefreshingTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token.Valid() {
+		return s.token, nil
+	}
+	token, err := s.src.Token()
+	if err != nil {
+		return nil, err
+	}
+	s.token = token
+	return token, nil
+}
+
+// Invalidate drops the given token, if it is still the cached one, so that
+// the next call to Token gets a new one.
+func (s *RefreshingTokenSource) Invalidate(token *oauth2.Token) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token == token {
+		s.token = nil
+	}
+}
+
+type tokenSourceInjector struct {
+	rt http.RoundTripper
+	ts *RefreshingTokenSource
+}
+
+func (i *tokenSourceInjector) Unwrap() http.RoundTripper {
+	return i.rt
+}
+
+func (i *tokenSourceInjector) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := i.ts.Token()
+	if err != nil {
+		return nil, err
+	}
+	res, err := i.rt.RoundTrip(authorize(req, token))
+	if err != nil || res.StatusCode != http.StatusUnauthorized {
+		return res, err
+	}
+
+	// The token may have been revoked before it expired, so retry once with
+	// a new one if the body can be sent again.
+	i.ts.Invalidate(token)
+	if req.Body != nil && req.GetBody == nil {
+		return res, nil
+	}
+	newReq := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return res, nil
+		}
+		newReq.Body = body
+	}
+	newToken, err := i.ts.Token()
+	if err != nil || newToken.AccessToken == token.AccessToken {
+		// The source cached the token too.
+		return res, nil
+	}
+	_, _ = io.Copy(io.Discard, res.Body)
+	_ = res.Body.Close()
+	return i.rt.RoundTrip(authorize(newReq, newToken))
+}
+
+func authorize(req *http.Request, token *oauth2.Token) *http.Request {
+	newReq := req.Clone(req.Context())
+	token.SetAuthHeader(newReq)
+	return newReq
+}
+
+// ApplyTokenSource authorizes the requests of the client with the tokens of
+// the source, which are cached until they expire. Requests rejected with 401
+// Unauthorized are sent again once with a new token.
+func ApplyTokenSource(client *http.Client, ts oauth2.TokenSource) {
+	client.Transport = &tokenSourceInjector{
+		rt: GetRoundTripper(client),
+		ts: NewRefreshingTokenSource(ts),
+	}
+}
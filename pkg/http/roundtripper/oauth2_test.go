@@ -0,0 +1,134 @@
+package roundtripper
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// newTokenServer returns a fake token endpoint that issues the tokens
+// "token-1", "token-2", ... valid for the given number of seconds.
+func newTokenServer(t *testing.T, expiresIn int, issued *int32) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		n := atomic.AddInt32(issued, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":%d}`, n, expiresIn)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// tokenSourceFunc gets a new token on every call.
+type tokenSourceFunc func() (*oauth2.Token, error)
+
+func (f tokenSourceFunc) Token() (*oauth2.Token, error) {
+	return f()
+}
+
+func TestTokenSource(t *testing.T) {
+	testSets := map[string]struct {
+		cachingSource  bool
+		expiresIn      int
+		rejected       map[string]bool
+		requests       int
+		expectedStatus int
+		expectedTokens []string
+		expectedIssued int32
+	}{
+		"cached token": {
+			expiresIn:      3600,
+			requests:       2,
+			expectedStatus: http.StatusOK,
+			expectedTokens: []string{"Bearer token-1", "Bearer token-1"},
+			expectedIssued: 1,
+		},
+		"token about to expire": {
+			// Tokens are refreshed 10 seconds before they expire.
+			expiresIn:      5,
+			requests:       2,
+			expectedStatus: http.StatusOK,
+			expectedTokens: []string{"Bearer token-1", "Bearer token-2"},
+			expectedIssued: 2,
+		},
+		"revoked token": {
+			expiresIn:      3600,
+			rejected:       map[string]bool{"Bearer token-1": true},
+			requests:       2,
+			expectedStatus: http.StatusOK,
+			expectedTokens: []string{"Bearer token-1", "Bearer token-2", "Bearer token-2"},
+			expectedIssued: 2,
+		},
+		"revoked token of a caching source": {
+			cachingSource:  true,
+			expiresIn:      3600,
+			rejected:       map[string]bool{"Bearer token-1": true},
+			requests:       1,
+			expectedStatus: http.StatusUnauthorized,
+			expectedTokens: []string{"Bearer token-1"},
+			expectedIssued: 1,
+		},
+		"unauthorized client": {
+			expiresIn:      3600,
+			rejected:       map[string]bool{"Bearer token-1": true, "Bearer token-2": true},
+			requests:       1,
+			expectedStatus: http.StatusUnauthorized,
+			expectedTokens: []string{"Bearer token-1", "Bearer token-2"},
+			expectedIssued: 2,
+		},
+	}
+	for name, ts := range testSets {
+		t.Run(name, func(t *testing.T) {
+			var issued int32
+			tokenSrv := newTokenServer(t, ts.expiresIn, &issued)
+			var tokens []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				auth := req.Header.Get("Authorization")
+				tokens = append(tokens, auth)
+				if ts.rejected[auth] {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				// Echo the body, which must be sent again on retries.
+				_, _ = io.Copy(w, req.Body)
+			}))
+			defer srv.Close()
+
+			cfg := &clientcredentials.Config{
+				ClientID:     "client",
+				ClientSecret: "secret",
+				TokenURL:     tokenSrv.URL,
+			}
+			var src oauth2.TokenSource = tokenSourceFunc(func() (*oauth2.Token, error) {
+				return cfg.Token(context.TODO())
+			})
+			if ts.cachingSource {
+				src = cfg.TokenSource(context.TODO())
+			}
+			c := &http.Client{}
+			ApplyTokenSource(c, src)
+
+			for i := 0; i < ts.requests; i++ {
+				res, err := c.Post(srv.URL, "text/plain", strings.NewReader("body"))
+				require.NoError(t, err)
+				body, err := io.ReadAll(res.Body)
+				require.NoError(t, err)
+				_ = res.Body.Close()
+				require.Equal(t, ts.expectedStatus, res.StatusCode)
+				if res.StatusCode == http.StatusOK {
+					require.Equal(t, "body", string(body))
+				}
+			}
+			require.Equal(t, ts.expectedTokens, tokens)
+			require.Equal(t, ts.expectedIssued, atomic.LoadInt32(&issued))
+		})
+	}
+}